@@ -0,0 +1,156 @@
+package cron
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// simulatedClock is a clock where waiting advances the current time immediately, rather than waiting for the test. Each
+// wait oversleeps by a varying amount, like a real timer would.
+type simulatedClock struct {
+	lock  sync.Mutex
+	now   time.Time
+	waits int
+}
+
+func (c *simulatedClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *simulatedClock) After(d time.Duration) <-chan time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.waits++
+	// Oversleep by between 0 and 300ms
+	c.now = c.now.Add(d + time.Duration(c.waits*7919%300)*time.Millisecond)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+// Advance simulates time passing while the tab is busy, such as when running a job
+func (c *simulatedClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestAccuracy(t *testing.T) {
+	t.Parallel()
+
+	// Starting part way through a minute, just before the start of a minute, and just after the start of a minute
+	testAccuracy(t, time.Date(2021, time.January, 1, 23, 57, 42, 123000000, time.UTC))
+	testAccuracy(t, time.Date(2021, time.January, 1, 23, 57, 59, 950000000, time.UTC))
+	testAccuracy(t, time.Date(2021, time.January, 1, 23, 58, 0, 900000000, time.UTC))
+}
+
+// testAccuracy starts a tab at the given time and checks that every job was dispatched within 1 second of the start of
+// the minute it matched
+func testAccuracy(t *testing.T, start time.Time) {
+	clk := &simulatedClock{now: start}
+
+	var lock sync.Mutex
+	dispatched := map[string][]time.Time{}
+	record := func(name string, busy time.Duration) func() {
+		return func() {
+			lock.Lock()
+			dispatched[name] = append(dispatched[name], clk.Now())
+			lock.Unlock()
+			clk.Advance(busy)
+		}
+	}
+	tab, _ := New([]Job{
+		{
+			Name:    "Midnight",
+			Pattern: "0 0 * * *",
+			Exec:    record("Midnight", 400*time.Millisecond),
+		},
+		{
+			Name:    "Minute",
+			Pattern: "* * * * *",
+			Exec:    record("Minute", 700*time.Millisecond),
+		},
+	})
+	tab.TZ = time.UTC
+	tab.Sequential = true
+	tab.clock = clk
+	expireAfter := time.Date(2021, time.January, 2, 0, 10, 30, 0, time.UTC)
+	tab.ExpireAfter = &expireAfter
+
+	tab.Start()
+
+	lock.Lock()
+	defer lock.Unlock()
+	expect := func(name string, first time.Time, count int) {
+		runs := dispatched[name]
+		if len(runs) != count {
+			t.Errorf("Unexpected number of runs for %s started at %s. Expected %d got %d: %v", name, start, count, len(runs), runs)
+			return
+		}
+		for i, run := range runs {
+			boundary := first.Add(time.Duration(i) * time.Minute)
+			if late := run.Sub(boundary); late < 0 || late >= time.Second {
+				t.Errorf("Job %s started at %s dispatched %s after %s, expected within 1s", name, start, late, boundary)
+			}
+		}
+	}
+	expect("Midnight", time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC), 1)
+	expect("Minute", time.Date(2021, time.January, 1, 23, 58, 0, 0, time.UTC), 13)
+}
+
+func TestNextTick(t *testing.T) {
+	t.Parallel()
+
+	expect := func(now time.Time, interval time.Duration, expected time.Time) {
+		if next := nextTick(now, interval); !next.Equal(expected) {
+			t.Errorf("Unexpected next tick after %s every %s. Expected %s got %s", now, interval, expected, next)
+		}
+	}
+	noon := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	expect(noon, time.Minute, noon.Add(time.Minute))
+	expect(noon.Add(300*time.Millisecond), time.Minute, noon.Add(time.Minute))
+	// The system clock was moved back by 100ms just before the start of the minute, so the tab woke up early
+	expect(noon.Add(59900*time.Millisecond), time.Minute, noon.Add(time.Minute))
+	expect(noon.Add(16*time.Second), 15*time.Second, noon.Add(30*time.Second))
+
+	// The tab realigns to the wall clock on every tick, so the next tick must not keep the monotonic clock reading,
+	// which is printed as m=
+	now := time.Now()
+	next := nextTick(now, time.Minute)
+	if strings.Contains(next.String(), "m=") {
+		t.Errorf("Next tick kept the monotonic clock reading: %s", next)
+	}
+	if wait := next.Sub(now); wait <= 0 || wait > time.Minute {
+		t.Errorf("Unexpected wait until the next tick: %s", wait)
+	}
+}
+
+func TestLoopRealignsAfterClockChange(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := New([]Job{
+		{
+			Name:    "EveryMinute",
+			Pattern: "* * * * *",
+			Exec:    func() {},
+		},
+	})
+	tab.TZ = time.UTC
+
+	start := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	// The system clock is moved back by 100ms while the tab is waiting for the next minute
+	clk.tick(start.Add(time.Minute - 100*time.Millisecond))
+	wake := <-clk.waiting
+	waited := clk.lastAfter
+	wake <- clk.Now()
+	stop()
+
+	if waited != 100*time.Millisecond {
+		t.Errorf("Tab did not realign to the start of the minute. Expected to wait %s got %s", 100*time.Millisecond, waited)
+	}
+}
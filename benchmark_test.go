@@ -10,3 +10,20 @@ func BenchmarkWouldRunAtTime(b *testing.B) {
 		patternDoesMatch(getRealPattern("*/5 0 1 JAN *"), time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
 	}
 }
+
+func BenchmarkWouldRunNow(b *testing.B) {
+	job := Job{Pattern: "*/5 0 1 JAN *"}
+	clock := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for n := 0; n < b.N; n++ {
+		job.WouldRunNow(WithTime(clock))
+	}
+}
+
+func BenchmarkMatchParsed(b *testing.B) {
+	minute, hour, dayOfMonth, month, dayOfWeek := Job{Pattern: "*/5 0 1 JAN *"}.Fields()
+	parsed := []string{minute, hour, dayOfMonth, month, dayOfWeek}
+	clock := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for n := 0; n < b.N; n++ {
+		MatchParsed(parsed, clock)
+	}
+}
@@ -0,0 +1,34 @@
+package cron
+
+// Builder provides a fluent way to describe the jobs of a tab. Any validation errors are deferred until Build is
+// called.
+type Builder struct {
+	jobs []Job
+}
+
+// NewBuilder returns a new, empty, builder
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// Add adds a new job with the given pattern, name, and method to the builder
+func (b *Builder) Add(pattern string, name string, exec func()) *Builder {
+	return b.AddJob(Job{
+		Pattern: pattern,
+		Name:    name,
+		Exec:    exec,
+	})
+}
+
+// AddJob adds the given job to the builder, for when more than a pattern, name, and method is needed
+func (b *Builder) AddJob(job Job) *Builder {
+	b.jobs = append(b.jobs, job)
+	return b
+}
+
+// Build validates all jobs added to the builder and returns a new tab, as if the jobs were passed to New
+func (b *Builder) Build() (*Tab, error) {
+	jobs := make([]Job, len(b.jobs))
+	copy(jobs, b.jobs)
+	return New(jobs)
+}
@@ -0,0 +1,50 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Parallel()
+
+	tab, err := cron.NewBuilder().
+		Add("0 2 * * *", "backup", func() {}).
+		Add("*/5 * * * *", "poll", func() {}).
+		AddJob(cron.Job{Name: "heartbeat", Every: 30 * time.Second, Exec: func() {}}).
+		Build()
+	if err != nil {
+		t.Fatalf("Unexpected error building tab: %s", err.Error())
+	}
+	if len(tab.Jobs) != 3 {
+		t.Fatalf("Unexpected number of jobs. Expected %d got %d", 3, len(tab.Jobs))
+	}
+	if tab.Jobs[0].Name != "backup" || tab.Jobs[0].Pattern != "0 2 * * *" {
+		t.Errorf("Unexpected first job %+v", tab.Jobs[0])
+	}
+	if tab.Interval != 60*time.Second {
+		t.Errorf("Unexpected tab interval %s", tab.Interval)
+	}
+}
+
+func TestBuilderInvalid(t *testing.T) {
+	t.Parallel()
+
+	builder := cron.NewBuilder().
+		Add("0 2 * * *", "backup", func() {}).
+		Add("0 25 * * *", "invalid", func() {})
+
+	tab, err := builder.Build()
+	if err == nil {
+		t.Fatalf("No error seen for invalid pattern")
+	}
+	if tab != nil {
+		t.Errorf("Unexpected tab returned for invalid pattern")
+	}
+
+	if _, err := cron.NewBuilder().Add("* * * * *", "dup", func() {}).Add("* * * * *", "dup", func() {}).Build(); err == nil {
+		t.Errorf("No error seen for duplicate job names")
+	}
+}
@@ -0,0 +1,32 @@
+package cron
+
+import (
+	"time"
+)
+
+// clock provides the current time and timers to a tab, allowing the passage of time to be simulated in tests
+type clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time on the returned channel
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is a clock backed by the system clock
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// getClock returns the clock used by this tab
+func (s *Tab) getClock() clock {
+	if s.clock == nil {
+		return realClock{}
+	}
+	return s.clock
+}
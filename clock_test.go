@@ -0,0 +1,1155 @@
+package cron
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock where the current time is set by the test and the tab only wakes up when the test ticks it
+type fakeClock struct {
+	lock    sync.Mutex
+	now     time.Time
+	waiting chan chan time.Time
+	// The duration of the last call to After
+	lastAfter time.Duration
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{
+		now:     now,
+		waiting: make(chan chan time.Time),
+	}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.lock.Lock()
+	c.lastAfter = d
+	c.lock.Unlock()
+	ch := make(chan time.Time, 1)
+	c.waiting <- ch
+	return ch
+}
+
+func (c *fakeClock) Set(now time.Time) {
+	c.lock.Lock()
+	c.now = now
+	c.lock.Unlock()
+}
+
+// tick waits for the tab to finish its current iteration, then sets the current time and wakes up the tab
+func (c *fakeClock) tick(now time.Time) {
+	ch := <-c.waiting
+	c.Set(now)
+	ch <- now
+}
+
+// startFakeTab starts the tab using a fake clock set to the given time, returning the clock and a function to stop the
+// tab and wait for all jobs to finish
+func startFakeTab(tab *Tab, now time.Time) (*fakeClock, func()) {
+	clk := newFakeClock(now)
+	tab.clock = clk
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	tab.loops.Add(1)
+	go func() {
+		tab.loop(done)
+		close(exited)
+	}()
+	return clk, func() {
+		close(done)
+		for {
+			select {
+			case ch := <-clk.waiting:
+				ch <- clk.Now()
+			case <-exited:
+				tab.inFlight.Wait()
+				return
+			}
+		}
+	}
+}
+
+func TestClockJumpNoDuplicates(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+	tab, _ := New([]Job{
+		{
+			Name:    "Noon",
+			Pattern: "0 12 * * *",
+			Exec: func() {
+				runs.Add(1)
+			},
+		},
+	})
+	tab.TZ = time.UTC
+
+	clk, stop := startFakeTab(tab, time.Date(2021, time.January, 1, 11, 59, 30, 0, time.UTC))
+	clk.tick(time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC))
+	// The system clock is moved backwards, and then passes noon again
+	clk.tick(time.Date(2021, time.January, 1, 11, 59, 0, 0, time.UTC))
+	clk.tick(time.Date(2021, time.January, 1, 12, 0, 30, 0, time.UTC))
+	clk.tick(time.Date(2021, time.January, 1, 12, 1, 0, 0, time.UTC))
+	clk.tick(time.Date(2021, time.January, 2, 12, 0, 0, 0, time.UTC))
+	clk.tick(time.Date(2021, time.January, 2, 12, 1, 0, 0, time.UTC))
+	stop()
+
+	if r := runs.Load(); r != 2 {
+		t.Errorf("Unexpected number of runs after clock jump. Expected %d got %d", 2, r)
+	}
+}
+
+func TestSubMinuteIntervalRunsOncePerMinute(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+	tab, _ := New([]Job{
+		{
+			Name:    "EveryMinute",
+			Pattern: "* * * * *",
+			Exec: func() {
+				runs.Add(1)
+			},
+		},
+	})
+	tab.TZ = time.UTC
+	tab.Interval = 15 * time.Second
+
+	start := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	if tab.HandledMinute("EveryMinute", start) {
+		t.Errorf("Minute should not be handled before the tab starts")
+	}
+
+	clk, stop := startFakeTab(tab, start)
+	for i := 1; i < 8; i++ {
+		clk.tick(start.Add(time.Duration(i) * tab.Interval))
+	}
+	clk.tick(start.Add(2 * time.Minute))
+	stop()
+
+	if r := runs.Load(); r != 3 {
+		t.Errorf("Unexpected number of runs for sub-minute interval. Expected %d got %d", 3, r)
+	}
+	if !tab.HandledMinute("EveryMinute", start.Add(30*time.Second)) {
+		t.Errorf("Minute should be handled after the job ran")
+	}
+	if tab.HandledMinute("EveryMinute", start.Add(3*time.Minute)) {
+		t.Errorf("Future minute should not be handled")
+	}
+	if tab.HandledMinute("UnknownJob", start) {
+		t.Errorf("Unknown job should not have handled any minute")
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+	var dryRuns atomic.Int32
+	tab, _ := New([]Job{
+		{
+			Name:    "DryRunJob",
+			Pattern: "* * * * *",
+			Exec: func() {
+				runs.Add(1)
+			},
+		},
+	})
+	tab.TZ = time.UTC
+	tab.DryRun = true
+	tab.OnJobStart = func(job Job, dryRun bool) {
+		if !dryRun {
+			t.Errorf("OnJobStart called without dry run flag")
+		}
+		if job.Name != "DryRunJob" {
+			t.Errorf("Unexpected job name '%s'", job.Name)
+		}
+		dryRuns.Add(1)
+	}
+
+	start := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	clk.tick(start.Add(1 * time.Minute))
+	clk.tick(start.Add(2 * time.Minute))
+	stop()
+
+	if r := runs.Load(); r != 0 {
+		t.Errorf("Exec called %d times in dry run mode", r)
+	}
+	if r := dryRuns.Load(); r != 3 {
+		t.Errorf("Unexpected number of dry runs. Expected %d got %d", 3, r)
+	}
+	if tab.TotalRuns() != 0 {
+		t.Errorf("Dry runs should not count towards total runs")
+	}
+}
+
+func TestSecondsStepUnalignedInterval(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+	tab, err := New([]Job{
+		{
+			Name:    "EveryFifteenSeconds",
+			Pattern: "*/15 * * * * *",
+			Seconds: true,
+			Exec: func() {
+				runs.Add(1)
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error creating tab: %s", err.Error())
+	}
+	tab.TZ = time.UTC
+	tab.Interval = 1 * time.Second
+
+	// Start the tab part way through a second so that ticks are not aligned to the start of the minute
+	start := time.Date(2021, time.January, 1, 12, 0, 7, 300, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	for i := 1; i < 60; i++ {
+		clk.tick(start.Add(time.Duration(i) * time.Second))
+	}
+	stop()
+
+	if r := runs.Load(); r != 4 {
+		t.Errorf("Unexpected number of runs for seconds step pattern. Expected %d got %d", 4, r)
+	}
+}
+
+func TestEveryAnchor(t *testing.T) {
+	t.Parallel()
+
+	anchor := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var runs atomic.Int32
+	tab, _ := New([]Job{
+		{
+			Name:        "EverySixHours",
+			Every:       6 * time.Hour,
+			EveryAnchor: &anchor,
+			Exec: func() {
+				runs.Add(1)
+			},
+		},
+	})
+	tab.TZ = time.UTC
+	tab.Interval = time.Hour
+
+	// Start the tab at a time that isn't aligned with the anchor, and well after it
+	start := time.Date(2021, time.March, 1, 3, 17, 0, 0, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	for i := 1; i <= 34; i++ {
+		clk.tick(start.Add(time.Duration(i) * time.Hour))
+	}
+	stop()
+
+	// 06:00, 12:00, 18:00, 00:00, 06:00, 12:00
+	if r := runs.Load(); r != 6 {
+		t.Errorf("Unexpected number of runs for anchored job. Expected %d got %d", 6, r)
+	}
+	expected := time.Date(2021, time.March, 2, 12, 0, 0, 0, time.UTC)
+	if lastFire := tab.jobStates[0].lastFire; !lastFire.Equal(expected) {
+		t.Errorf("Anchored job not aligned to anchor. Last run %s expected %s", lastFire, expected)
+	}
+}
+
+func TestEveryAnchorInFuture(t *testing.T) {
+	t.Parallel()
+
+	anchor := time.Date(2021, time.January, 1, 12, 5, 0, 0, time.UTC)
+	var runs atomic.Int32
+	tab, _ := New([]Job{
+		{
+			Name:        "EveryTenMinutes",
+			Every:       10 * time.Minute,
+			EveryAnchor: &anchor,
+			Exec: func() {
+				runs.Add(1)
+			},
+		},
+	})
+	tab.TZ = time.UTC
+
+	start := time.Date(2021, time.January, 1, 11, 50, 0, 0, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	for i := 1; i <= 20; i++ {
+		clk.tick(start.Add(time.Duration(i) * time.Minute))
+	}
+	stop()
+
+	// 11:55, 12:05
+	if r := runs.Load(); r != 2 {
+		t.Errorf("Unexpected number of runs for job anchored in the future. Expected %d got %d", 2, r)
+	}
+}
+
+func TestMissedTicks(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := New([]Job{
+		{
+			Name:    "EveryMinute",
+			Pattern: "* * * * *",
+			Exec:    func() {},
+		},
+	})
+	tab.TZ = time.UTC
+
+	type missedTicks struct {
+		from   time.Time
+		to     time.Time
+		missed int
+	}
+	reports := []missedTicks{}
+	tab.OnMissedTicks = func(from, to time.Time, missed int) {
+		reports = append(reports, missedTicks{from, to, missed})
+	}
+
+	start := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	clk.tick(start.Add(1 * time.Minute))
+	// The system sleeps for 9 minutes
+	clk.tick(start.Add(10 * time.Minute))
+	clk.tick(start.Add(11 * time.Minute))
+	stop()
+
+	if len(reports) != 1 {
+		t.Fatalf("Unexpected number of missed tick reports. Expected %d got %d", 1, len(reports))
+	}
+	report := reports[0]
+	if report.missed != 8 {
+		t.Errorf("Unexpected number of missed ticks. Expected %d got %d", 8, report.missed)
+	}
+	if !report.from.Equal(start.Add(2 * time.Minute)) {
+		t.Errorf("Unexpected start of missed ticks %s", report.from)
+	}
+	if !report.to.Equal(start.Add(10 * time.Minute)) {
+		t.Errorf("Unexpected end of missed ticks %s", report.to)
+	}
+}
+
+func TestMissedTicksMonotonic(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := New([]Job{
+		{
+			Name:    "EveryMinute",
+			Pattern: "* * * * *",
+			Exec:    func() {},
+		},
+	})
+	tab.TZ = time.UTC
+
+	missed := 0
+	tab.OnMissedTicks = func(from, to time.Time, m int) {
+		missed += m
+	}
+
+	// Times from the system clock carry a monotonic clock reading, which the tab must not rely on to detect a sleep
+	now := time.Now()
+	start := now.Add(now.Truncate(time.Minute).Sub(now))
+	clk, stop := startFakeTab(tab, start)
+	clk.tick(start.Add(1 * time.Minute))
+	clk.tick(start.Add(10 * time.Minute))
+	clk.tick(start.Add(11 * time.Minute))
+	stop()
+
+	if missed != 8 {
+		t.Errorf("Unexpected number of missed ticks. Expected %d got %d", 8, missed)
+	}
+}
+
+func TestExpireAfterInTabTimezone(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+	tab, _ := New([]Job{
+		{
+			Name:    "EveryMinute",
+			Pattern: "* * * * *",
+			Exec: func() {
+				runs.Add(1)
+			},
+		},
+	})
+	tokyo := time.FixedZone("JST", 9*60*60)
+	tab.TZ = tokyo
+	// Midnight on new year's day in Tokyo, which is 15:00 UTC the day before
+	expireAfter := time.Date(2021, time.January, 1, 0, 0, 0, 0, tokyo)
+	tab.ExpireAfter = &expireAfter
+
+	clk, stop := startFakeTab(tab, time.Date(2020, time.December, 31, 14, 58, 30, 0, time.UTC))
+	clk.tick(time.Date(2020, time.December, 31, 14, 59, 0, 0, time.UTC))
+	clk.tick(time.Date(2020, time.December, 31, 15, 0, 0, 0, time.UTC))
+	clk.tick(time.Date(2020, time.December, 31, 15, 1, 0, 0, time.UTC))
+	stop()
+
+	// The job runs at 14:58, 14:59, and 15:00 UTC, but not after the tab expires
+	if runs.Load() != 3 {
+		t.Errorf("Unexpected number of runs. Expected %d got %d", 3, runs.Load())
+	}
+}
+
+func TestEveryPattern(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+	tab, err := New([]Job{
+		{
+			Name:    "Every90Seconds",
+			Pattern: "@every 90s",
+			Exec: func() {
+				runs.Add(1)
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	tab.TZ = time.UTC
+	tab.Interval = 30 * time.Second
+
+	start := time.Date(2021, time.January, 1, 12, 0, 10, 0, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	for i := 1; i <= 6; i++ {
+		clk.tick(start.Add(time.Duration(i) * 30 * time.Second))
+	}
+	stop()
+
+	// 90 and 180 seconds after the tab started
+	if r := runs.Load(); r != 2 {
+		t.Errorf("Unexpected number of runs for @every job. Expected %d got %d", 2, r)
+	}
+	expected := start.Add(180 * time.Second)
+	if lastFire := tab.jobStates[0].lastFire; !lastFire.Equal(expected) {
+		t.Errorf("Unexpected last run %s expected %s", lastFire, expected)
+	}
+}
+
+func TestRunLimit(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+	var unlimitedRuns atomic.Int32
+	tab, _ := New([]Job{
+		{
+			Name:     "Limited",
+			Pattern:  "* * * * *",
+			RunLimit: 2,
+			Exec: func() {
+				runs.Add(1)
+			},
+		},
+		{
+			Name:    "Unlimited",
+			Pattern: "* * * * *",
+			Exec: func() {
+				unlimitedRuns.Add(1)
+			},
+		},
+	})
+	tab.TZ = time.UTC
+
+	start := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	for i := 1; i <= 3; i++ {
+		clk.tick(start.Add(time.Duration(i) * time.Minute))
+	}
+	stop()
+
+	if r := runs.Load(); r != 2 {
+		t.Errorf("Unexpected number of runs for limited job. Expected %d got %d", 2, r)
+	}
+	if r := unlimitedRuns.Load(); r != 4 {
+		t.Errorf("Unexpected number of runs for unlimited job. Expected %d got %d", 4, r)
+	}
+}
+
+func TestRunLimitDryRun(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+	tab, _ := New([]Job{
+		{
+			Name:     "Limited",
+			Pattern:  "* * * * *",
+			RunLimit: 1,
+			Exec: func() {
+				runs.Add(1)
+			},
+		},
+	})
+	tab.TZ = time.UTC
+	tab.DryRun = true
+
+	start := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	wake := <-clk.waiting
+	tab.DryRun = false
+	clk.Set(start.Add(time.Minute))
+	wake <- clk.Now()
+	clk.tick(start.Add(2 * time.Minute))
+	stop()
+
+	if r := runs.Load(); r != 1 {
+		t.Errorf("Unexpected number of runs after dry run. Expected %d got %d", 1, r)
+	}
+}
+
+func TestOnExpire(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	newTab := func() (*Tab, *atomic.Int32) {
+		tab, _ := New([]Job{
+			{
+				Name:    "EveryMinute",
+				Pattern: "* * * * *",
+				Exec:    func() {},
+			},
+		})
+		tab.TZ = time.UTC
+		expireAfter := start.Add(90 * time.Second)
+		tab.ExpireAfter = &expireAfter
+		expired := &atomic.Int32{}
+		tab.OnExpire = func() {
+			expired.Add(1)
+		}
+		return tab, expired
+	}
+
+	tab, expired := newTab()
+	clk, stop := startFakeTab(tab, start)
+	clk.tick(start.Add(1 * time.Minute))
+	clk.tick(start.Add(2 * time.Minute))
+	stop()
+	if e := expired.Load(); e != 1 {
+		t.Errorf("Unexpected number of expiry callbacks. Expected %d got %d", 1, e)
+	}
+
+	// Stopping the tab before it expires should not invoke the callback
+	tab, expired = newTab()
+	clk, stop = startFakeTab(tab, start)
+	clk.tick(start.Add(1 * time.Minute))
+	stop()
+	if e := expired.Load(); e != 0 {
+		t.Errorf("Expiry callback invoked for stopped tab")
+	}
+}
+
+func TestAtResolverTab(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+	tab, err := New([]Job{
+		{
+			Name: "Sunset",
+			At: func(day time.Time) (time.Time, bool) {
+				return day.Add(18*time.Hour + 30*time.Minute), true
+			},
+			Exec: func() {
+				runs.Add(1)
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	tab.TZ = time.UTC
+
+	start := time.Date(2021, time.January, 1, 18, 28, 0, 0, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	for i := 1; i <= 4; i++ {
+		clk.tick(start.Add(time.Duration(i) * time.Minute))
+	}
+	stop()
+
+	if r := runs.Load(); r != 1 {
+		t.Errorf("Unexpected number of runs. Expected %d got %d", 1, r)
+	}
+}
+
+func TestStartDelay(t *testing.T) {
+	t.Parallel()
+
+	expect := func(now time.Time, interval time.Duration, expected time.Duration) {
+		if actual := startDelay(now, interval); actual != expected {
+			t.Errorf("Unexpected start delay at %s with interval %s. Expected %s got %s", now, interval, expected, actual)
+		}
+	}
+
+	minute := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	expect(minute.Add(45*time.Second+500*time.Millisecond), time.Minute, 14*time.Second+500*time.Millisecond)
+	expect(minute.Add(1*time.Second), 5*time.Minute, 59*time.Second)
+	expect(minute.Add(40*time.Second), 15*time.Second, 5*time.Second)
+	expect(minute, time.Minute, 0)
+	expect(minute.Add(500*time.Millisecond), time.Minute, 0)
+	expect(minute.Add(1*time.Second), time.Minute, 59*time.Second)
+	expect(minute.Add(40*time.Second+50*time.Millisecond), 15*time.Second, 4*time.Second+950*time.Millisecond)
+	expect(minute.Add(45*time.Second+50*time.Millisecond), 15*time.Second, 0)
+
+	// The tab should wait for exactly the start delay before starting
+	expectStart := func(start time.Time, expected time.Duration) {
+		tab, _ := New([]Job{
+			{
+				Name:    "Job",
+				Pattern: "* * * * *",
+				Exec:    func() {},
+			},
+		})
+		tab.TZ = time.UTC
+		expired := start.Add(-time.Hour)
+		tab.ExpireAfter = &expired
+		clk := newFakeClock(start)
+		tab.clock = clk
+
+		returned := make(chan struct{})
+		go func() {
+			tab.Start()
+			close(returned)
+		}()
+		select {
+		case ch := <-clk.waiting:
+			clk.lock.Lock()
+			waited := clk.lastAfter
+			clk.lock.Unlock()
+			if waited != expected {
+				t.Errorf("Unexpected wait before starting at %s. Expected %s got %s", start, expected, waited)
+			}
+			clk.Set(start.Add(waited))
+			ch <- clk.Now()
+			<-returned
+		case <-returned:
+			if expected != 0 {
+				t.Errorf("Tab started at %s without waiting. Expected to wait %s", start, expected)
+			}
+		}
+	}
+	expectStart(minute.Add(45*time.Second+500*time.Millisecond), 14*time.Second+500*time.Millisecond)
+	expectStart(minute.Add(1*time.Second), 59*time.Second)
+	expectStart(minute.Add(500*time.Millisecond), 0)
+	expectStart(minute, 0)
+}
+
+func TestJobLocations(t *testing.T) {
+	t.Parallel()
+
+	london := time.FixedZone("London", 0)
+	paris := time.FixedZone("Paris", 60*60)
+	alsoLondon := time.FixedZone("Lisbon", 0)
+
+	var runs atomic.Int32
+	var dedupedRuns atomic.Int32
+	tab, _ := New([]Job{
+		{
+			Name:      "Offices",
+			Pattern:   "0 9 * * *",
+			Locations: []*time.Location{london, paris},
+			Exec: func() {
+				runs.Add(1)
+			},
+		},
+		{
+			Name:      "SameOffset",
+			Pattern:   "0 9 * * *",
+			Locations: []*time.Location{london, alsoLondon},
+			Exec: func() {
+				dedupedRuns.Add(1)
+			},
+		},
+	})
+	tab.TZ = time.UTC
+
+	start := time.Date(2021, time.January, 1, 7, 58, 0, 0, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	for i := 1; i <= 64; i++ {
+		clk.tick(start.Add(time.Duration(i) * time.Minute))
+	}
+	stop()
+
+	if r := runs.Load(); r != 2 {
+		t.Errorf("Unexpected number of runs for job in two timezones. Expected %d got %d", 2, r)
+	}
+	if r := dedupedRuns.Load(); r != 1 {
+		t.Errorf("Unexpected number of runs for job in timezones with the same offset. Expected %d got %d", 1, r)
+	}
+
+	next, ok := tab.Jobs[0].NextRun(start)
+	if !ok || !next.Equal(time.Date(2021, time.January, 1, 8, 0, 0, 0, time.UTC)) || next.Location() != time.UTC {
+		t.Errorf("Unexpected next run %s", next)
+	}
+	runsBetween := tab.Jobs[0].RunsBetween(start, start.Add(24*time.Hour))
+	if len(runsBetween) != 2 {
+		t.Errorf("Unexpected runs in a day: %v", runsBetween)
+	}
+}
+
+func TestOnSkip(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	run := func(configure func(tab *Tab), jobs ...Job) map[SkipReason]int {
+		var lock sync.Mutex
+		reasons := map[SkipReason]int{}
+		tab, _ := New(jobs)
+		tab.TZ = time.UTC
+		tab.OnSkip = func(job Job, reason SkipReason) {
+			lock.Lock()
+			reasons[reason]++
+			lock.Unlock()
+		}
+		configure(tab)
+		clk, stop := startFakeTab(tab, start)
+		clk.tick(start.Add(time.Minute))
+		stop()
+		lock.Lock()
+		defer lock.Unlock()
+		return reasons
+	}
+	expect := func(reasons map[SkipReason]int, reason SkipReason, expected int) {
+		if reasons[reason] != expected {
+			t.Errorf("Unexpected number of skips for reason %s. Expected %d got %d: %v", reason, expected, reasons[reason], reasons)
+		}
+	}
+
+	reasons := run(func(tab *Tab) {
+		tab.DryRun = true
+	}, Job{Name: "DryRun", Pattern: "* * * * *", Exec: func() {}})
+	expect(reasons, SkipDryRun, 2)
+
+	reasons = run(func(tab *Tab) {}, Job{Name: "Limited", Pattern: "* * * * *", RunLimit: 1, Exec: func() {}})
+	expect(reasons, SkipRunLimit, 1)
+
+	reasons = run(func(tab *Tab) {
+		tab.Jitter = time.Hour
+	}, Job{Name: "Jittered", Pattern: "* * * * *", Exec: func() {}})
+	expect(reasons, SkipStopped, 2)
+
+	release := make(chan struct{})
+	blocked := func() {
+		<-release
+	}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+	}()
+	reasons = run(func(tab *Tab) {
+		tab.Workers = 1
+		tab.QueueSize = 1
+		tab.QueuePolicy = QueueDropOldest
+	}, Job{Name: "A", Pattern: "* * * * *", Exec: blocked}, Job{Name: "B", Pattern: "* * * * *", Exec: blocked}, Job{Name: "C", Pattern: "* * * * *", Exec: blocked})
+	if reasons[SkipQueueFull] < 1 {
+		t.Errorf("Expected jobs to be skipped because the queue was full: %v", reasons)
+	}
+}
+
+func TestHolidays(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+	var skipped atomic.Int32
+	tab, _ := New([]Job{
+		{
+			Name:    "Daily",
+			Pattern: "0 0 * * *",
+			Holiday: Holidays(time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC)),
+			Exec: func() {
+				runs.Add(1)
+			},
+		},
+	})
+	tab.TZ = time.UTC
+	tab.OnSkip = func(job Job, reason SkipReason) {
+		if reason == SkipHoliday {
+			skipped.Add(1)
+		}
+	}
+
+	start := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	clk.tick(start.AddDate(0, 0, 1))
+	clk.tick(start.AddDate(0, 0, 2))
+	stop()
+
+	if r := runs.Load(); r != 2 {
+		t.Errorf("Unexpected number of runs. Expected %d got %d", 2, r)
+	}
+	if s := skipped.Load(); s != 1 {
+		t.Errorf("Unexpected number of holiday skips. Expected %d got %d", 1, s)
+	}
+
+	holiday := Holidays(time.Date(2021, time.December, 25, 0, 0, 0, 0, time.UTC))
+	if !holiday(time.Date(2021, time.December, 25, 23, 59, 0, 0, time.UTC)) || holiday(time.Date(2021, time.December, 26, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected result from holidays method")
+	}
+}
+
+func TestExecAt(t *testing.T) {
+	t.Parallel()
+
+	var lock sync.Mutex
+	scheduled := map[string][]time.Time{}
+	record := func(name string) func(time.Time) {
+		return func(scheduledFor time.Time) {
+			lock.Lock()
+			defer lock.Unlock()
+			scheduled[name] = append(scheduled[name], scheduledFor)
+		}
+	}
+	anchor := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	tab, _ := New([]Job{
+		{
+			Name:    "Minute",
+			Pattern: "* * * * *",
+			ExecAt:  record("Minute"),
+		},
+		{
+			Name:        "Every",
+			Every:       2 * time.Minute,
+			EveryAnchor: &anchor,
+			ExecAt:      record("Every"),
+		},
+	})
+	tab.TZ = time.UTC
+	tab.Sequential = true
+	tab.Jitter = 20 * time.Millisecond
+
+	// The tab wakes up late into each minute, but jobs are passed the minute they were scheduled for
+	start := time.Date(2021, time.January, 1, 12, 0, 25, 0, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	clk.tick(start.Add(time.Minute))
+	clk.tick(start.Add(2 * time.Minute))
+	stop()
+
+	expect := func(name string, expected ...time.Time) {
+		lock.Lock()
+		defer lock.Unlock()
+		actual := scheduled[name]
+		if len(actual) != len(expected) {
+			t.Errorf("Unexpected scheduled times for %s. Expected %v got %v", name, expected, actual)
+			return
+		}
+		for i := range expected {
+			if !actual[i].Equal(expected[i]) {
+				t.Errorf("Unexpected scheduled time for %s. Expected %s got %s", name, expected[i], actual[i])
+			}
+		}
+	}
+	expect("Minute", anchor, anchor.Add(time.Minute), anchor.Add(2*time.Minute))
+	expect("Every", anchor.Add(2*time.Minute))
+}
+
+func TestSkipAfterError(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+	var skipped atomic.Int32
+	tab, _ := New([]Job{
+		{
+			Name:           "Failing",
+			Pattern:        "* * * * *",
+			SkipAfterError: true,
+			ExecE: func() error {
+				if runs.Add(1) == 1 {
+					return fmt.Errorf("broken dependency")
+				}
+				return nil
+			},
+		},
+	})
+	tab.TZ = time.UTC
+	tab.Sequential = true
+	tab.OnSkip = func(job Job, reason SkipReason) {
+		if reason == SkipFailed {
+			skipped.Add(1)
+		}
+	}
+
+	start := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	clk.tick(start.Add(time.Minute))
+	// Wait for the tab to finish checking the skipped minute before clearing the error
+	wake := <-clk.waiting
+	if !tab.Failed("Failing") {
+		t.Errorf("Job not reported as failed after returning an error")
+	}
+	if due := tab.DueAt(start.Add(2 * time.Minute)); len(due) != 0 {
+		t.Errorf("Failed job reported as due")
+	}
+	if failed := tab.Status().FailedJobs; len(failed) != 1 || failed[0] != "Failing" {
+		t.Errorf("Unexpected failed jobs in status %v", failed)
+	}
+	tab.ClearError("Failing")
+	if due := tab.DueAt(start.Add(2 * time.Minute)); len(due) != 1 {
+		t.Errorf("Job not reported as due after its error was cleared")
+	}
+	if failed := tab.Status().FailedJobs; len(failed) != 0 {
+		t.Errorf("Unexpected failed jobs in status after clearing the error %v", failed)
+	}
+	clk.Set(start.Add(2 * time.Minute))
+	wake <- clk.Now()
+	clk.tick(start.Add(3 * time.Minute))
+	stop()
+
+	if r := runs.Load(); r != 3 {
+		t.Errorf("Unexpected number of runs. Expected %d got %d", 3, r)
+	}
+	if s := skipped.Load(); s != 1 {
+		t.Errorf("Unexpected number of skips. Expected %d got %d", 1, s)
+	}
+	if tab.Failed("Failing") {
+		t.Errorf("Job reported as failed after its error was cleared")
+	}
+}
+
+func TestWindowTab(t *testing.T) {
+	t.Parallel()
+
+	var lock sync.Mutex
+	ran := []time.Time{}
+	tab, _ := New([]Job{
+		{
+			Name:    "Business",
+			Pattern: "* * * * *",
+			Window:  BusinessHours(),
+			ExecAt: func(scheduledFor time.Time) {
+				lock.Lock()
+				defer lock.Unlock()
+				ran = append(ran, scheduledFor)
+			},
+		},
+	})
+	tab.TZ = time.UTC
+	tab.Sequential = true
+
+	// Friday afternoon until Monday morning
+	start := time.Date(2021, time.January, 8, 16, 58, 0, 0, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	for _, clock := range []time.Time{
+		time.Date(2021, time.January, 8, 16, 59, 0, 0, time.UTC),
+		time.Date(2021, time.January, 8, 17, 0, 0, 0, time.UTC),
+		time.Date(2021, time.January, 9, 12, 0, 0, 0, time.UTC),
+		time.Date(2021, time.January, 11, 8, 59, 0, 0, time.UTC),
+		time.Date(2021, time.January, 11, 9, 0, 0, 0, time.UTC),
+	} {
+		clk.tick(clock)
+	}
+	stop()
+
+	lock.Lock()
+	defer lock.Unlock()
+	expected := []time.Time{
+		time.Date(2021, time.January, 8, 16, 58, 0, 0, time.UTC),
+		time.Date(2021, time.January, 8, 16, 59, 0, 0, time.UTC),
+		time.Date(2021, time.January, 11, 9, 0, 0, 0, time.UTC),
+	}
+	if len(ran) != len(expected) {
+		t.Fatalf("Unexpected runs. Expected %v got %v", expected, ran)
+	}
+	for i := range expected {
+		if !ran[i].Equal(expected[i]) {
+			t.Errorf("Unexpected run. Expected %s got %s", expected[i], ran[i])
+		}
+	}
+}
+
+func TestStopSoonCancel(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+	tab, _ := New([]Job{
+		{
+			Name:    "Minute",
+			Pattern: "* * * * *",
+			Exec: func() {
+				runs.Add(1)
+			},
+		},
+	})
+	tab.TZ = time.UTC
+	tab.Sequential = true
+	expireAfter := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tab.ExpireAfter = &expireAfter
+
+	start := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	defer stop()
+
+	// Wait for the tab to finish its first iteration before stopping it
+	wake := <-clk.waiting
+	tab.StopSoon()
+	tab.StopSoon()
+	if !tab.CancelStop() {
+		t.Errorf("No stop was cancelled")
+	}
+	if tab.CancelStop() {
+		t.Errorf("Stop cancelled more than once")
+	}
+	clk.Set(start.Add(time.Minute))
+	wake <- clk.Now()
+	clk.tick(start.Add(2 * time.Minute))
+
+	wake = <-clk.waiting
+	if !tab.Running() {
+		t.Errorf("Tab stopped after the stop was cancelled")
+	}
+	if r := runs.Load(); r != 3 {
+		t.Errorf("Unexpected number of runs. Expected %d got %d", 3, r)
+	}
+	if tab.ExpireAfter == nil || !tab.ExpireAfter.Equal(expireAfter) {
+		t.Errorf("Expiry changed by StopSoon: %v", tab.ExpireAfter)
+	}
+
+	tab.StopSoon()
+	clk.Set(start.Add(3 * time.Minute))
+	wake <- clk.Now()
+	tab.Wait()
+	if tab.Running() {
+		t.Errorf("Tab still running after StopSoon")
+	}
+	if r := runs.Load(); r != 3 {
+		t.Errorf("Job ran after StopSoon. Expected %d runs got %d", 3, r)
+	}
+	if !tab.ExpireAfter.Equal(expireAfter) {
+		t.Errorf("Expiry changed by StopSoon: %v", tab.ExpireAfter)
+	}
+}
+
+func TestPauseGroup(t *testing.T) {
+	t.Parallel()
+
+	var maintenanceRuns atomic.Int32
+	var reportRuns atomic.Int32
+	var paused atomic.Int32
+	tab, _ := New([]Job{
+		{
+			Name:    "Vacuum",
+			Group:   "maintenance",
+			Pattern: "* * * * *",
+			Exec: func() {
+				maintenanceRuns.Add(1)
+			},
+		},
+		{
+			Name:    "Backup",
+			Group:   "maintenance",
+			Pattern: "* * * * *",
+			Exec: func() {
+				maintenanceRuns.Add(1)
+			},
+		},
+		{
+			Name:    "Sales",
+			Group:   "reports",
+			Pattern: "* * * * *",
+			Exec: func() {
+				reportRuns.Add(1)
+			},
+		},
+	})
+	tab.TZ = time.UTC
+	tab.Sequential = true
+	tab.OnSkip = func(job Job, reason SkipReason) {
+		if reason == SkipPaused {
+			paused.Add(1)
+		}
+	}
+
+	start := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	defer stop()
+
+	// Wait for the first minute to finish before pausing the group
+	wake := <-clk.waiting
+	tab.PauseGroup("maintenance")
+	clk.Set(start.Add(time.Minute))
+	wake <- clk.Now()
+	clk.tick(start.Add(2 * time.Minute))
+
+	wake = <-clk.waiting
+	if !tab.GroupPaused("maintenance") || tab.GroupPaused("reports") {
+		t.Errorf("Unexpected paused groups")
+	}
+	if r := maintenanceRuns.Load(); r != 2 {
+		t.Errorf("Unexpected runs of paused group. Expected %d got %d", 2, r)
+	}
+	if r := reportRuns.Load(); r != 3 {
+		t.Errorf("Unexpected runs of running group. Expected %d got %d", 3, r)
+	}
+	if p := paused.Load(); p != 4 {
+		t.Errorf("Unexpected number of paused skips. Expected %d got %d", 4, p)
+	}
+
+	tab.ResumeGroup("maintenance")
+	clk.Set(start.Add(3 * time.Minute))
+	wake <- clk.Now()
+	wake = <-clk.waiting
+	if r := maintenanceRuns.Load(); r != 4 {
+		t.Errorf("Unexpected runs of resumed group. Expected %d got %d", 4, r)
+	}
+
+	stats := tab.GroupStats("maintenance")
+	if stats.Paused || stats.Jobs != 2 || stats.Runs != 4 || stats.Running != 0 {
+		t.Errorf("Unexpected group stats %+v", stats)
+	}
+	if stats.LastRun == nil || !stats.LastRun.Equal(start.Add(3*time.Minute)) {
+		t.Errorf("Unexpected last run of group %v", stats.LastRun)
+	}
+	if stats := tab.GroupStats("reports"); stats.Jobs != 1 || stats.Runs != 4 {
+		t.Errorf("Unexpected group stats %+v", stats)
+	}
+	if stats := tab.GroupStats("unknown"); stats.Jobs != 0 || stats.LastRun != nil {
+		t.Errorf("Unexpected stats for unknown group %+v", stats)
+	}
+	wake <- clk.Now()
+}
+
+func TestWaitDuringStartDelay(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := New([]Job{
+		{
+			Name:    "EveryMinute",
+			Pattern: "* * * * *",
+			Exec:    func() {},
+		},
+	})
+	tab.TZ = time.UTC
+	start := time.Date(2021, time.January, 1, 12, 0, 30, 0, time.UTC)
+	expireAfter := start.Add(10 * time.Second)
+	tab.ExpireAfter = &expireAfter
+	clk := newFakeClock(start)
+	tab.clock = clk
+
+	go tab.Start()
+	// Start is waiting for the start of the next minute
+	wake := <-clk.waiting
+
+	waited := make(chan struct{})
+	go func() {
+		tab.Wait()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+		t.Fatalf("Wait returned while Start was waiting for the start of the minute")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clk.Set(start.Add(30 * time.Second))
+	wake <- clk.Now()
+	select {
+	case <-waited:
+	case <-time.After(time.Second):
+		t.Errorf("Wait did not return after the tab expired")
+	}
+}
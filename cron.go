@@ -16,16 +16,43 @@
 // so know that the only real gotcha with this quirk is that there is no way to have a job run on a schedule such as
 // 'every Friday the 13th'. It would instead run on every Friday and the 13th of each month.
 //
+// Days that only exist in some months are matched against the calendar, so 0 0 29 2 * runs only on February 29th in
+// leap years, and 0 0 31 * * runs only in months with 31 days. The day of week quirk still applies, so 0 0 29 2 SUN
+// runs on every Sunday in February, as well as on February 29th in leap years.
+//
 // If the component is a numerical value, then the same component (minute, hour, month, etc...) of the current time must
 // match the exact value for the component. If the component is a range, the current time value must fall between that
-// range. If the component is a comma-separated list of numerical values, the current time must match any one of the
-// values.
+// range. If the component is a comma-separated list, the current time must match any one of the elements of the list,
+// where each element can be a numerical value, a range, or a pattern.
 //
-// Month and Day of Week values can also be the first three letters of the english name of that unit. For example,
-// JAN for January or THU for Thursday.
+// Month and Day of Week values can also be the first three letters, or the full english name of that unit, in any
+// case. For example, JAN, jan, or January for January, or THU or Thursday for Thursday. Named values can also be used
+// in ranges, such as MON-FRI, and in lists, such as MON,WED,FRI. Lists can mix names, numbers, ranges of either, and
+// steps, such as MON-WED,FRI for Monday, Tuesday, Wednesday, and Friday. Day of Week ranges may wrap around the end of
+// the week, such as FRI-MON for Friday, Saturday, Sunday, and Monday.
 //
 // Components can also be an pattern for a mod operation, such as */5 or */2. Where if the remainder from the
-// current times component and the pattern is zero, it matches.
+// current times component and the pattern is zero, it matches. A pattern can also be applied to a range, such as
+// 1-10/3, which matches every 3rd value starting from the start of the range, or to a single value, such as 7/30, which
+// matches every 30th value starting from that value until the end of the component (7 and 37 for minutes).
+//
+// The day of month component can also be the nearest weekday to a day of the month, such as 15W, which matches the
+// weekday closest to the 15th: the 14th if the 15th is a Saturday, or the 16th if it is a Sunday. LW matches the last
+// weekday of the month. The nearest weekday is never in a different month, so 1W on a Saturday matches Monday the 3rd.
+//
+// Any component can also be a hash, H, which resolves to a value chosen from the name of the job. This spreads out jobs
+// that would otherwise all run at the same time, such as H * * * * which runs once an hour at a minute that is the same
+// every hour for a job but differs between jobs. A hash can be limited to a range, such as H(0-29). A hashed day of
+// month is between 1 and 28.
+//
+// The hour component can also be written in 12-hour form with an AM or PM suffix, such as 9AM or 5PM, including in
+// ranges and lists, such as 9AM-5PM. 12AM is midnight and 12PM is noon. The suffix is not case sensitive.
+//
+// Any component can also be ~, which resolves to a random value chosen once when the tab is created, such as
+// ~ */2 * * * which runs every 2 hours at a random minute. A random day of month is between 1 and 28. The value stays
+// the same for as long as the process runs, including for clones of the tab and for jobs added after the tab was
+// created, but jobs with the same name and pattern share the same value. Use SetSeed on the tab to choose the same
+// random values every time the process runs.
 //
 // Lastly, components can be a wildcard *, which will match any value.
 //
@@ -38,13 +65,25 @@
 //	"* */2 * * *" Run every 2 hours
 //	"0 9-17 * * *" Run every day at the start every hour between 9AM to 5PM
 //	"0 3,5,7 * * *" Run every day at 3AM, 5AM, and 7AM
+//	"0 9AM-5PM * * *" Run every day at the start every hour between 9AM to 5PM
+//
+// A pattern can also be one of the following shorthands:
+//
+//	"@weekdays" Run every minute from Monday to Friday, the same as "* * * * 1-5"
+//	"@weekends" Run every minute on Saturday and Sunday, the same as "* * * * 0,6"
+//
+// Jobs can optionally include an additional leading component for the second (0-59) by setting Seconds on the job. A
+// pattern in the seconds component is matched against the second of the current time, so */15 always matches at 0, 15,
+// 30, and 45 seconds regardless of when the tab was started.
 //
 // This package conforms to the POSIX crontab standard, which can be found here:
 // https://pubs.opengroup.org/onlinepubs/9699919799/utilities/crontab.html
 //
 // Cron wakes up each minute to check for any jobs to run, then sleeps for the remainder of the minute. Under normal
-// circumstances cron is accurate up-to 1 second. Each job's method is called in a unique goroutine and will recover
-// from any panics.
+// circumstances cron is accurate up-to 1 second. By default each job's method is called in a unique goroutine. If the
+// tab is Sequential, due jobs are instead called one after another on the tab's goroutine, in the order they appear in
+// Jobs. If the tab has Workers, due jobs are queued and called by that many goroutines. In every mode, the job's method
+// will recover from any panics.
 //
 // By default, Cron operates using the local timezone as determined by Golang, but this can be changed with the TZ field
 // of a Tab object.
@@ -52,9 +91,12 @@ package cron
 
 import (
 	"fmt"
+	"math/rand"
 	"runtime/debug"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ecnepsnai/logtic"
@@ -64,36 +106,196 @@ var log = logtic.Log.Connect("cron")
 
 // Tab describes a group of jobs, known as a "Tab"
 type Tab struct {
-	// The jobs to run
+	// The jobs to run. Jobs are always evaluated in the order they appear, so jobs due at the same time are started in
+	// this order. Unless the tab is Sequential, jobs run concurrently and may not execute in this order.
 	Jobs []Job
-	// Optional time when the schedule should expire. Set to nil for no expiry date.
+	// Optional time when the schedule should expire. Set to nil for no expiry date. The expiry is an absolute instant, so
+	// it may be created in any location, such as the location of TZ, and TZ does not change when the tab expires.
 	ExpireAfter *time.Time
 	// The frequency to check if the jobs should run. By default this is 60 seconds and should not be changed.
 	Interval time.Duration
 	// The timezone to use when checking if jobs should run. Defaults to the local timezone as determined by Go.
 	TZ *time.Location
+	// Variables assigned in the crontab this tab was parsed from. Only populated by Parse or ParseFile.
+	Variables map[string]string
+	// If true, jobs that are due are logged and passed to OnJobStart but never executed
+	DryRun bool
+	// If true, a panic from a job is re-raised after it has been logged, which will crash the process. By default panics
+	// are recovered and the tab continues running.
+	RepanicOnJobPanic bool
+	// Optional method to invoke right before a job is executed. If the tab is in DryRun mode, this is invoked with
+	// dryRun set to true for each job that would have run.
+	OnJobStart func(job Job, dryRun bool)
+	// Optional methods to wrap the execution of every job, such as for logging or metrics. Each middleware must call next
+	// to continue to the next middleware, and eventually the job itself. The first middleware is the outermost. Panics
+	// are still recovered outside of all middleware.
+	Middleware []func(job Job, next func())
+	// Optional method to receive lifecycle events for all jobs in this tab
+	EventSink func(event Event)
+	// Optional method to invoke when the tab wakes up to find that more than one interval has passed, such as after the
+	// system was asleep. From is when the first missed tick should have happened, to is when the tab woke up, and
+	// missed is how many ticks were skipped. Jobs are not run for any missed ticks.
+	OnMissedTicks func(from, to time.Time, missed int)
+	// Optional method to invoke when the tab stops because it has passed ExpireAfter. Not invoked when the tab is
+	// stopped any other way.
+	OnExpire func()
+	// If true, Start will immediately run any jobs whose pattern matches the current time before waiting for the start of
+	// the next minute. Jobs using Every are not run early.
+	RunOnStartIfDue bool
+	// If true, jobs are run one after another in the order they appear in Jobs, rather than each in their own goroutine.
+	// A slow job will delay any other jobs due at the same time, and if a job takes longer than the interval of the tab
+	// then ticks may be missed.
+	Sequential bool
+	// Optional maximum random delay before each job is executed, to avoid many jobs due at the same time all starting at
+	// once. Jobs that are still waiting when the tab stops are not run. Set to 0 to disable.
+	Jitter time.Duration
+	// Optional duration after which a job is considered slow. Jobs that take longer than this are logged once they finish
+	// and passed to OnSlow. Slow jobs are not stopped. Set to 0 to disable.
+	SlowThreshold time.Duration
+	// Optional method to invoke when a job finishes after taking longer than SlowThreshold
+	OnSlow func(job Job, elapsed time.Duration)
+	// Optional first day of the week for numerical day of week values in patterns. For example, when set to Monday, 0
+	// is Monday and 6 is Sunday. Named values, such as MON, are not affected. Only affects when the tab runs jobs and
+	// DueAt; methods of Job, such as NextRun and WouldRunNow, always count from Sunday. Defaults to Sunday.
+	WeekStart time.Weekday
+	// Optional method to invoke when a job was due but was not executed, with the reason it was skipped
+	OnSkip func(job Job, reason SkipReason)
+	// Optional maximum number of jobs to execute at once. When set, due jobs are added to a queue that is processed by
+	// this many goroutines, rather than each job running in its own goroutine. Ignored if the tab is Sequential. Set to
+	// 0 for no limit.
+	Workers int
+	// Optional number of due jobs that can wait in the queue when using Workers. Defaults to the number of workers.
+	QueueSize int
+	// What happens when a job is due but the queue is full when using Workers. Defaults to QueueBlock.
+	QueuePolicy QueuePolicy
+
+	lock          sync.Mutex
+	running       bool
+	jobsRunning   map[string]int
+	jobRuns       map[string]uint64
+	lastRuns      map[string]time.Time
+	jobPanics     map[string]uint64
+	failedJobs    map[string]bool
+	pausedGroups  map[string]bool
+	totalRuns     atomic.Uint64
+	stopRequested atomic.Bool
+	inFlight      sync.WaitGroup
+	loops         sync.WaitGroup
+	stopped       chan struct{}
+	queue         chan Job
+	seed          *int64
+	startedAt     time.Time
+	jobStates     []jobState
+	clock         clock
+}
+
+// jobState describes the internal scheduling state of a job in a tab
+type jobState struct {
+	// The last time a job using Every was run
+	lastFire time.Time
+	// The last minute (or second, for jobs using Seconds) that a job using a pattern was run for
+	lastMatch time.Time
+	// The number of times the job was due and dispatched, used to enforce the run limit of the job
+	dispatched int
 }
 
 // Job describes a single job that will run based on the pattern
 type Job struct {
 	// Cron pattern describing the schedule of this job
-	Pattern string
-	// The name of this job, only used for logging
-	Name string
+	Pattern string `json:"pattern"`
+	// Optional additional patterns for this job. The job runs if any of its patterns match.
+	Patterns []string `json:"patterns,omitempty"`
+	// If true, Pattern has an additional leading component for the second (0-59). The interval of the tab must be
+	// reduced for these jobs to run at the expected second.
+	Seconds bool `json:"seconds,omitempty"`
+	// The name of this job, used for logging and to reference the job. Names must be unique within a tab.
+	Name string `json:"name"`
+	// Optional human readable description of this job. Has no effect on scheduling.
+	Description string `json:"description,omitempty"`
+	// Optional name of the group this job belongs to, such as "maintenance". Every job in a group can be paused and
+	// resumed together with PauseGroup and ResumeGroup.
+	Group string `json:"group,omitempty"`
+	// Optional tags for this job, such as the team that owns it. Tags are passed through to hooks and events and have
+	// no effect on scheduling.
+	Tags map[string]string `json:"tags,omitempty"`
+	// Optional fixed interval to run this job at, relative to when the tab was started. When set, Pattern is ignored.
+	// Alternatively, Pattern can be set to @every followed by a duration, such as @every 1h30m.
+	// The job runs no more frequently than the Interval of the tab, so the interval of the tab should be less than or
+	// equal to this value.
+	Every time.Duration `json:"every,omitempty"`
+	// Optional time to align a job using Every to, instead of when the tab was started. The job runs at the anchor plus
+	// any multiple of Every, so the times the job runs at are the same regardless of when the tab was started. Set to
+	// nil to align to when the tab was started.
+	EveryAnchor *time.Time `json:"every_anchor,omitempty"`
+	// Optional method that returns the time this job should run on the given day, such as sunset at a location. The
+	// day is midnight in the timezone of the tab, and the job runs at the minute of the returned time. Return false to
+	// not run the job on that day. When set, Pattern is ignored and Every must not be set.
+	At func(day time.Time) (time.Time, bool) `json:"-"`
+	// Optional timezones to evaluate the pattern of this job in, instead of the timezone of the tab. The job runs if the
+	// pattern matches the current time in any of the timezones, but no more than once per minute. For example,
+	// 0 9 * * * with two timezones runs at 9AM in each of them. The times returned by NextRun and RunsBetween consider
+	// every timezone, however WouldRunNow only considers the timezone that is evaluated.
+	Locations []*time.Location `json:"-"`
+	// Optional method that returns true if the given time, in the timezone of the tab, is on a holiday. The job does not
+	// run on holidays even when its pattern matches, and is skipped with SkipHoliday instead. Use Holidays to skip a
+	// list of dates. Holidays are not considered by WouldRunNow or NextRun.
+	Holiday func(t time.Time) bool `json:"-"`
+	// Optional period of each day that this job is limited to, such as BusinessHours. The job only runs when its
+	// pattern matches and the time is within the window. For jobs using Every, the window is in the timezone of the
+	// tab. Set to nil to not limit when the job runs.
+	Window *Window `json:"window,omitempty"`
+	// Optional maximum number of times this job will run for the lifetime of the tab, after which it is skipped even
+	// when due. Set to 0 for no limit.
+	RunLimit int `json:"run_limit,omitempty"`
+	// Optional number of times to retry this job when it panics or ExecE returns an error. A successful attempt stops
+	// retrying. Set to 0 to never retry.
+	Retries int `json:"retries,omitempty"`
+	// Optional time to wait before the first retry of this job, which doubles for each following retry. Set to 0 to
+	// retry immediately.
+	RetryBackoff time.Duration `json:"retry_backoff,omitempty"`
+	// If true and this job panics or ExecE returns an error, after any retries, then the job is skipped with
+	// SkipFailed until ClearError is called with its name. Jobs using this should have a name.
+	SkipAfterError bool `json:"skip_after_error,omitempty"`
 	// The method to invoke when the job runs
-	Exec func()
+	Exec func() `json:"-"`
+	// Optional method to invoke when the job runs that is passed the time the job was scheduled for, in the timezone of
+	// the tab. This is the minute the pattern matched, or the interval a job using Every is running for, rather than
+	// the current time, so it is not affected by Jitter or by the job waiting for a worker. When set, Exec is ignored.
+	ExecAt func(scheduledFor time.Time) `json:"-"`
+	// Optional method to invoke when the job runs that can return an error, in which case the error is logged and the
+	// job is retried according to Retries. When set, Exec and ExecAt are ignored.
+	ExecE func() error `json:"-"`
 
-	pattern []string
+	parsed       []parsedPattern
+	scheduledFor time.Time
+}
+
+// parsedPattern is a validated pattern with any named values converted to their numerical values
+type parsedPattern struct {
+	// The 5 components of the pattern
+	components []string
+	// The seconds component, or empty if the pattern does not include seconds
+	seconds string
 }
 
 // New create a new cron instance (known as a "tab") for the given slice of jobs but do not start it.
-// Error is only populated if there is a validation error on any of the job patterns.
+// Error is only populated if there is a validation error on any of the job patterns, or if more than one job shares
+// the same non-empty name. Jobs without a name are permitted but can't be referenced by name.
+//
+// A tab with no jobs is permitted. Starting it does nothing other than wake up every interval until it is stopped or
+// expires, and a warning is logged when it starts.
 func New(Jobs []Job) (*Tab, error) {
-	for _, job := range Jobs {
+	if err := validateJobNames(Jobs); err != nil {
+		return nil, err
+	}
+	for i, job := range Jobs {
 		if err := job.Validate(); err != nil {
 			return nil, err
 		}
-		job.pattern = getRealPattern(job.Pattern)
+		if job.every() > 0 {
+			continue
+		}
+		Jobs[i].parsed = job.parse()
 	}
 
 	return &Tab{
@@ -104,89 +306,902 @@ func New(Jobs []Job) (*Tab, error) {
 	}, nil
 }
 
-// Start will wait until the next minute (up to 60 seconds) and then start the tab. This is the optimal way to start
-// the tab since jobs will run at the start of the minute.
+// NewStrict will create a new tab for the given jobs like New, but will also return an error if any job has a pattern
+// that can never run, such as 0 0 30 2 *. See Job.ValidateStrict.
+func NewStrict(Jobs []Job) (*Tab, error) {
+	for _, job := range Jobs {
+		if err := job.ValidateStrict(); err != nil {
+			return nil, err
+		}
+	}
+	return New(Jobs)
+}
+
+// Clone returns a copy of this tab that has not been started. The jobs of the clone are copied, so the jobs of either
+// tab can be changed without affecting the other. Any internal state, such as which jobs are running, is not copied.
+func (s *Tab) Clone() *Tab {
+	jobs := make([]Job, len(s.Jobs))
+	for i, job := range s.Jobs {
+		if job.Patterns != nil {
+			job.Patterns = append([]string{}, job.Patterns...)
+		}
+		if job.Locations != nil {
+			job.Locations = append([]*time.Location{}, job.Locations...)
+		}
+		if job.Tags != nil {
+			job.Tags = copyStringMap(job.Tags)
+		}
+		if job.EveryAnchor != nil {
+			anchor := *job.EveryAnchor
+			job.EveryAnchor = &anchor
+		}
+		if job.Window != nil {
+			window := *job.Window
+			if window.Weekdays != nil {
+				window.Weekdays = append([]time.Weekday{}, window.Weekdays...)
+			}
+			job.Window = &window
+		}
+		if job.parsed != nil {
+			// Copy the parsed patterns rather than parsing again, so that the clone has the same random values
+			parsed := make([]parsedPattern, len(job.parsed))
+			for p, pattern := range job.parsed {
+				parsed[p] = parsedPattern{
+					components: append([]string{}, pattern.components...),
+					seconds:    pattern.seconds,
+				}
+			}
+			job.parsed = parsed
+		} else if job.Validate() == nil && job.every() <= 0 {
+			job.parsed = job.parse()
+		}
+		jobs[i] = job
+	}
+
+	var expireAfter *time.Time
+	if s.ExpireAfter != nil {
+		e := *s.ExpireAfter
+		expireAfter = &e
+	}
+
+	var variables map[string]string
+	if s.Variables != nil {
+		variables = copyStringMap(s.Variables)
+	}
+
+	var middleware []func(job Job, next func())
+	if s.Middleware != nil {
+		middleware = append(middleware, s.Middleware...)
+	}
+
+	clone := &Tab{
+		Jobs:              jobs,
+		ExpireAfter:       expireAfter,
+		Interval:          s.Interval,
+		TZ:                s.TZ,
+		Variables:         variables,
+		DryRun:            s.DryRun,
+		RepanicOnJobPanic: s.RepanicOnJobPanic,
+		OnJobStart:        s.OnJobStart,
+		Middleware:        middleware,
+		EventSink:         s.EventSink,
+		OnMissedTicks:     s.OnMissedTicks,
+		OnExpire:          s.OnExpire,
+		RunOnStartIfDue:   s.RunOnStartIfDue,
+		Sequential:        s.Sequential,
+		Jitter:            s.Jitter,
+		SlowThreshold:     s.SlowThreshold,
+		OnSlow:            s.OnSlow,
+		WeekStart:         s.WeekStart,
+		OnSkip:            s.OnSkip,
+		Workers:           s.Workers,
+		QueueSize:         s.QueueSize,
+		QueuePolicy:       s.QueuePolicy,
+		clock:             s.clock,
+	}
+	if s.seed != nil {
+		clone.SetSeed(*s.seed)
+	}
+	return clone
+}
+
+// Start will wait until the start of the next minute (up to 60 seconds) and then start the tab. This is the optimal way
+// to start the tab since jobs will run at the start of the minute. If the interval of the tab is less than a minute,
+// the tab instead waits until the next multiple of the interval.
+//
+// The tab is considered started as soon as Start is called, so Wait blocks while Start is waiting for the start of the
+// minute.
 //
 // This method blocks.
 func (s *Tab) Start() {
+	s.loops.Add(1)
+	clk := s.getClock()
+	if s.RunOnStartIfDue {
+		// Run any jobs matching the current minute now, rather than missing them while waiting for the next minute
+		now := clk.Now()
+		for i, job := range s.Jobs {
+			if job.every() == 0 && s.jobIsDue(i, job, now) {
+				s.dispatchDue(i, job, now)
+			}
+		}
+	}
+
 	// Wait until the next minute to start the tab
 	// This ensures that minute based jobs run at the top of the minute
-	waitDur := time.Duration(int(s.Interval.Seconds()) - time.Now().Second())
-	log.Debug("Starting tab in %d seconds", waitDur)
-	time.Sleep(waitDur * time.Second)
-	s.ForceStart()
+	wait := startDelay(clk.Now(), s.Interval)
+	if wait > 0 {
+		log.Debug("Starting tab in %s", wait)
+		<-clk.After(wait)
+	}
+	s.loop(nil)
 }
 
-// ForceStart will start the schedule immediately without waiting. This can have the undesired effect of jobs running
-// at most 60 seconds later than they would if you used `Start`.
+// startTolerance is how long after the start of a minute the tab can be started without waiting for the next minute
+const startTolerance = time.Second
+
+// startDelay returns how long to wait from now until the start of the next minute, or the next multiple of the
+// interval if it is less than a minute. Returns 0 if now is already at, or just after, the start. For intervals of less
+// than a minute the tolerance is reduced to a tenth of the interval.
+func startDelay(now time.Time, interval time.Duration) time.Duration {
+	align := alignment(interval)
+	tolerance := startTolerance
+	if align/10 < tolerance {
+		tolerance = align / 10
+	}
+	start := now.Truncate(align)
+	if now.Sub(start) < tolerance || start.Equal(now) {
+		return 0
+	}
+	return start.Add(align).Sub(now)
+}
+
+// alignment returns what the tab aligns its ticks to, which is the start of each minute, or each multiple of the
+// interval if it is less than a minute
+func alignment(interval time.Duration) time.Duration {
+	if interval > 0 && interval < time.Minute {
+		return interval
+	}
+	return time.Minute
+}
+
+// nextTick returns the next wall clock boundary of the interval after now, such as the start of the next minute. The
+// monotonic clock reading of now is ignored, so that the tab realigns itself to the wall clock after the system clock
+// is changed.
+func nextTick(now time.Time, interval time.Duration) time.Time {
+	return now.Round(0).Truncate(interval).Add(interval)
+}
+
+// ForceStart will start the schedule immediately without waiting. This can have the undesired effect of jobs due in
+// the current minute running at most 60 seconds later than they would if you used `Start`. Later minutes are checked
+// at the start of the minute.
 //
 // This method blocks.
 func (s *Tab) ForceStart() {
+	s.loops.Add(1)
+	s.loop(nil)
+}
+
+// ForceStartUntil will start the schedule immediately without waiting, like ForceStart, but returns promptly once the
+// done channel is closed rather than only when the tab expires. Jobs that are still executing are not waited for, use
+// Wait for that.
+//
+// This method blocks.
+func (s *Tab) ForceStartUntil(done <-chan struct{}) {
+	s.loops.Add(1)
+	s.loop(done)
+}
+
+// Run will start the schedule immediately in a new goroutine and return a function that will stop it. Calling the stop
+// function stops the tab promptly and then waits for any jobs that are still executing to finish.
+//
+// This method does not block.
+func (s *Tab) Run() (stop func()) {
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	s.loops.Add(1)
+	go func() {
+		s.loop(done)
+		close(exited)
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+		<-exited
+		s.inFlight.Wait()
+	}
+}
+
+// loop runs the schedule until the tab expires or the done channel is closed. A nil done channel is never closed. The
+// caller must add to the loops wait group before calling loop.
+//
+// The time to wake up for each iteration is based off of when the tab started using the monotonic clock, so that
+// changes to the system clock do not affect how often the tab wakes up, and so that the time spent evaluating jobs does
+// not cause the tab to drift. Every iteration after the first is aligned to the start of a minute (or a multiple of
+// the interval if it is less than a minute), so that a tab started part way through a minute does not stay that far
+// behind for as long as it runs. Patterns are still matched against the wall clock.
+func (s *Tab) loop(done <-chan struct{}) {
 	log.Debug("Started tab")
+	for _, warning := range s.Warnings() {
+		log.Warn("%s", warning)
+	}
+	clk := s.getClock()
+	start := clk.Now()
+	s.lock.Lock()
+	s.running = true
+	s.startedAt = start
+	stopped := make(chan struct{})
+	s.stopped = stopped
+	s.lock.Unlock()
+	defer s.loops.Done()
+	defer close(stopped)
+	defer s.setRunning(false)
+	defer s.stopWorkers()
 
+	next := start.Round(0).Truncate(s.Interval)
 	for {
-		if s.ExpireAfter != nil {
-			if time.Since(*s.ExpireAfter).Seconds() > 0 {
-				log.Debug("Tab expired")
-				return
+		now := clk.Now()
+		// The gap is measured on the wall clock, as the monotonic clock doesn't advance while the system is suspended
+		if behind := now.Round(0).Sub(next); s.Interval > 0 && behind >= s.Interval {
+			// More than one interval has passed since we were meant to wake up, such as when the system was asleep
+			missed := int(behind / s.Interval)
+			log.PWarn("Tab missed ticks", map[string]interface{}{
+				"missed": missed,
+				"from":   next.String(),
+				"to":     now.String(),
+			})
+			if s.OnMissedTicks != nil {
+				s.OnMissedTicks(next, now, missed)
 			}
 		}
 
-		for _, job := range s.Jobs {
-			if job.WouldRunNowInTZ(s.TZ) {
-				log.PDebug("Running job", map[string]interface{}{
-					"name":    job.Name,
-					"pattern": job.Pattern,
-				})
-				go s.runJob(job)
+		if s.stopRequested.CompareAndSwap(true, false) {
+			log.Debug("Tab stopped")
+			return
+		}
+
+		if s.expired(now) {
+			log.PDebug("Tab expired", map[string]interface{}{
+				"expire_after": s.ExpireAfter.In(s.location()).String(),
+			})
+			if s.OnExpire != nil {
+				s.OnExpire()
 			}
+			return
 		}
-		time.Sleep(s.Interval)
+
+		for i, job := range s.Jobs {
+			if s.jobIsDue(i, job, now) {
+				s.dispatchDue(i, job, now)
+			}
+		}
+
+		// Each tick is scheduled from the wall clock, but the wait itself is timed on the monotonic clock
+		wallNow := clk.Now().Round(0)
+		next = nextTick(wallNow, s.Interval)
+		wait := next.Sub(wallNow)
+
+		select {
+		case <-done:
+			log.Debug("Tab stopped")
+			return
+		case <-clk.After(wait):
+		}
+	}
+}
+
+// dispatchDue dispatches the job at index i, which is due at the given time, unless the job's group is paused, the time
+// is a holiday for the job, the job previously failed, or the job has reached its run limit
+func (s *Tab) dispatchDue(i int, job Job, now time.Time) {
+	if s.groupPaused(job) {
+		s.skip(job, SkipPaused)
+		return
+	}
+	if job.Holiday != nil && job.Holiday(now.In(s.location())) {
+		s.skip(job, SkipHoliday)
+		return
+	}
+	if s.skippedForError(job) {
+		s.skip(job, SkipFailed)
+		return
+	}
+	if !s.withinRunLimit(i, job) {
+		s.skip(job, SkipRunLimit)
+		return
+	}
+	job.scheduledFor = s.scheduledTime(i, job).In(s.location())
+	s.dispatchJob(job)
+}
+
+// scheduledTime returns the time the job at index i was last due for, which is the matched minute (or second) of a
+// job using a pattern, or the interval of a job using Every
+func (s *Tab) scheduledTime(i int, job Job) time.Time {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	state := s.jobState(i)
+	if job.every() > 0 {
+		return state.lastFire
+	}
+	return state.lastMatch
+}
+
+// withinRunLimit returns true if the job has been dispatched fewer times than its run limit, counting this dispatch
+// unless the tab is in DryRun mode, as the job won't actually run
+func (s *Tab) withinRunLimit(i int, job Job) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	state := s.jobState(i)
+	if job.RunLimit > 0 && state.dispatched >= job.RunLimit {
+		log.PDebug("Job has reached its run limit", map[string]interface{}{
+			"name":      job.Name,
+			"run_limit": job.RunLimit,
+		})
+		return false
+	}
+	if !s.DryRun {
+		state.dispatched++
+	}
+	return true
+}
+
+// dispatchJob runs the job according to the tab's options, either in a new goroutine, inline if the tab is sequential,
+// or not at all if the tab is in DryRun mode
+func (s *Tab) dispatchJob(job Job) {
+	if s.DryRun {
+		log.PDebug("Would run job", map[string]interface{}{
+			"name":    job.Name,
+			"pattern": job.Pattern,
+		})
+		if s.OnJobStart != nil {
+			s.OnJobStart(job, true)
+		}
+		s.skip(job, SkipDryRun)
+		return
+	}
+
+	log.PDebug("Running job", map[string]interface{}{
+		"name":    job.Name,
+		"pattern": job.Pattern,
+	})
+	if s.Sequential {
+		if s.waitJitter(job) {
+			s.runJob(job)
+		}
+		return
+	}
+	if s.Workers > 0 {
+		s.dispatchToWorkers(job)
+		return
+	}
+	s.inFlight.Add(1)
+	go func() {
+		defer s.inFlight.Done()
+		if s.waitJitter(job) {
+			s.runJob(job)
+		}
+	}()
+}
+
+// waitJitter waits for a random duration up to the Jitter of the tab. Returns false if the tab stopped while waiting,
+// in which case the job should not be run.
+func (s *Tab) waitJitter(job Job) bool {
+	if s.Jitter <= 0 {
+		return true
+	}
+
+	if !s.sleepUnlessStopped(time.Duration(rand.Int63n(int64(s.Jitter)))) {
+		s.skip(job, SkipStopped)
+		return false
+	}
+	return true
+}
+
+// sleepUnlessStopped waits for the given duration. Returns false if the tab stopped while waiting.
+func (s *Tab) sleepUnlessStopped(d time.Duration) bool {
+	s.lock.Lock()
+	stopped := s.stopped
+	s.lock.Unlock()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-stopped:
+		return false
+	}
+}
+
+// expired returns true if the tab has an expiry date and the current time is after it. Both are instants, so the
+// timezone of either doesn't matter.
+func (s *Tab) expired(now time.Time) bool {
+	if s.ExpireAfter == nil {
+		return false
+	}
+	return now.After(*s.ExpireAfter)
+}
+
+// location returns the timezone of the tab, defaulting to the local timezone if none is set
+func (s *Tab) location() *time.Location {
+	if s.TZ == nil {
+		return time.Local
+	}
+	return s.TZ
+}
+
+// jobIsDue returns true if the job at index i of the tab should run at the given time
+func (s *Tab) jobIsDue(i int, job Job, now time.Time) bool {
+	if job.every() > 0 {
+		if job.Window != nil && !job.Window.Contains(now.In(s.location())) {
+			return false
+		}
+		return s.everyJobIsDue(i, job, now)
+	}
+
+	if !s.withWeekStart(job).matchIn(now, s.location()) {
+		return false
+	}
+
+	// Never run a job more than once for the same minute, or for a minute before one it has already run for. This can
+	// happen if the system clock is changed backwards. Jobs using seconds are limited to once per second instead.
+	match := now.Truncate(time.Minute)
+	if job.Seconds {
+		match = now.Truncate(time.Second)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	state := s.jobState(i)
+	if !match.After(state.lastMatch) {
+		return false
+	}
+	state.lastMatch = match
+	return true
+}
+
+// everyJobIsDue returns true if the job at index i, which runs at a fixed interval, should run at the given time
+func (s *Tab) everyJobIsDue(i int, job Job, now time.Time) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	state := s.jobState(i)
+	every := job.every()
+
+	if job.EveryAnchor != nil {
+		// The most recent time, at or before now, that is aligned to the anchor
+		since := now.Sub(*job.EveryAnchor)
+		intervals := since / every
+		if since < 0 && since%every != 0 {
+			intervals--
+		}
+		aligned := job.EveryAnchor.Add(intervals * every)
+		if aligned.Before(s.startedAt) || !aligned.After(state.lastFire) {
+			return false
+		}
+		state.lastFire = aligned
+		return true
+	}
+
+	lastFire := state.lastFire
+	if lastFire.Before(s.startedAt) {
+		lastFire = s.startedAt
+	}
+	elapsed := now.Sub(lastFire)
+	if elapsed < every {
+		return false
+	}
+	// Advance by whole intervals so that the cadence doesn't drift if the tab wakes late
+	state.lastFire = lastFire.Add(every * (elapsed / every))
+	return true
+}
+
+// HandledMinute returns true if the named job has already been run for the minute containing the given time, or for a
+// later minute. Jobs using a pattern run at most once for any given minute, regardless of the tabs interval.
+func (s *Tab) HandledMinute(name string, t time.Time) bool {
+	i := s.jobIndex(name)
+	if i < 0 {
+		return false
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	lastMatch := s.jobState(i).lastMatch
+	if lastMatch.IsZero() {
+		return false
+	}
+	return !t.Truncate(time.Minute).After(lastMatch)
+}
+
+// jobIndex returns the index of the job with the given name, or -1 if no job has that name
+func (s *Tab) jobIndex(name string) int {
+	for i, job := range s.Jobs {
+		if job.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// jobState returns the state for the job at index i of the tab. The caller must hold the tab lock.
+func (s *Tab) jobState(i int) *jobState {
+	if len(s.jobStates) < len(s.Jobs) {
+		states := make([]jobState, len(s.Jobs))
+		copy(states, s.jobStates)
+		s.jobStates = states
 	}
+	return &s.jobStates[i]
 }
 
-// StopSoon will stop the tab in no more than 60 seconds
+// StopSoon will stop the tab the next time it wakes up, which is no more than the interval of the tab (60 seconds by
+// default). ExpireAfter is not changed. Calling StopSoon more than once has no further effect, and the stop can be
+// undone with CancelStop until the tab wakes up.
 func (s *Tab) StopSoon() {
-	e := time.Now().AddDate(-1, 0, 0)
-	s.ExpireAfter = &e
+	s.stopRequested.Store(true)
 }
 
-// WouldRunNow returns true if this job would run right now in the current timezone
-func (job Job) WouldRunNow() bool {
-	return job.WouldRunNowInTZ(time.Local)
+// CancelStop undoes a previous call to StopSoon, if the tab has not already stopped because of it. Returns true if a
+// stop was cancelled.
+func (s *Tab) CancelStop() bool {
+	return s.stopRequested.Swap(false)
 }
 
-// WouldRunNowInTZ returns true if this job would run right now in the given timezone
+// Wait blocks until the tab has stopped and every job that was executing has finished. Returns immediately if the tab
+// was never started.
+func (s *Tab) Wait() {
+	s.loops.Wait()
+	s.inFlight.Wait()
+}
+
+// Running returns true if the tab has been started and has not yet stopped
+func (s *Tab) Running() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.running
+}
+
+// IsRunning returns true if any job with the given name is currently executing
+func (s *Tab) IsRunning(name string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.jobsRunning[name] > 0
+}
+
+func (s *Tab) setRunning(running bool) {
+	s.lock.Lock()
+	s.running = running
+	s.lock.Unlock()
+}
+
+// markJobRunning increments or decrements the number of active executions of the named job
+func (s *Tab) markJobRunning(name string, running bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.jobsRunning == nil {
+		s.jobsRunning = map[string]int{}
+	}
+	if running {
+		s.jobsRunning[name]++
+	} else {
+		s.jobsRunning[name]--
+		if s.jobsRunning[name] <= 0 {
+			delete(s.jobsRunning, name)
+		}
+	}
+}
+
+// TotalRuns returns the number of job executions this tab has performed, regardless of if they panicked
+func (s *Tab) TotalRuns() uint64 {
+	return s.totalRuns.Load()
+}
+
+// JobRuns returns the number of times the named job has been executed by this tab, regardless of if it panicked
+func (s *Tab) JobRuns(name string) uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.jobRuns[name]
+}
+
+// LastRun returns the time the named job was last executed by this tab. False is returned if the job has not run.
+func (s *Tab) LastRun(name string) (time.Time, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	lastRun, ok := s.lastRuns[name]
+	return lastRun, ok
+}
+
+// JobPanics returns the number of times the named job has panicked while being executed by this tab, including any
+// attempts that were retried
+func (s *Tab) JobPanics(name string) uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.jobPanics[name]
+}
+
+// ResetStats clears the total number of runs, the number of runs and panics of each job, and the last time each job
+// ran. Jobs that are currently executing are still reported as running by IsRunning.
+func (s *Tab) ResetStats() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.totalRuns.Store(0)
+	s.jobRuns = nil
+	s.lastRuns = nil
+	s.jobPanics = nil
+}
+
+// recordRun updates the statistics for the named job being executed at the given time
+func (s *Tab) recordRun(name string, at time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.jobRuns == nil {
+		s.jobRuns = map[string]uint64{}
+	}
+	if s.lastRuns == nil {
+		s.lastRuns = map[string]time.Time{}
+	}
+	s.totalRuns.Add(1)
+	s.jobRuns[name]++
+	s.lastRuns[name] = at
+}
+
+// recordPanic updates the statistics for the named job panicking
+func (s *Tab) recordPanic(name string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.jobPanics == nil {
+		s.jobPanics = map[string]uint64{}
+	}
+	s.jobPanics[name]++
+}
+
+// DueNow returns the jobs whose patterns match the current time in the tab's timezone, without running them
+func (s *Tab) DueNow() []Job {
+	return s.DueAt(s.getClock().Now())
+}
+
+// DueAt returns the jobs whose patterns match the given time in the tab's timezone, without running them. Jobs are
+// returned in the order they appear in Jobs. Jobs using Every are never returned, as when they are due depends on when
+// the tab was started. Jobs in a group that is paused, and jobs using SkipAfterError that have failed, are not
+// returned.
+func (s *Tab) DueAt(t time.Time) []Job {
+	jobs := []Job{}
+	for _, job := range s.Jobs {
+		if s.groupPaused(job) || s.skippedForError(job) {
+			continue
+		}
+		if s.withWeekStart(job).matchIn(t, s.location()) {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+// String returns the name, pattern, and description of this job
+func (job Job) String() string {
+	pattern := strings.Join(job.patternStrings(), ", ")
+	if job.Description == "" {
+		return fmt.Sprintf("%s (%s)", job.Name, pattern)
+	}
+	return fmt.Sprintf("%s (%s): %s", job.Name, pattern, job.Description)
+}
+
+// WouldRunNow returns true if this job would run right now in the current timezone. Options can be provided to change
+// the time or timezone that is evaluated. Returns false if the pattern is not valid. Always returns false for jobs
+// that run at a fixed interval with Every, as those depend on when the tab was started.
+//
+// Every field, including the day of the week, is matched using the location of the evaluated time. A time provided
+// with WithTime in UTC is matched against the UTC day, not the local day, unless WithLocation is also provided.
+func (job Job) WouldRunNow(options ...MatchOption) bool {
+	return job.matchAt(matchTime(options))
+}
+
+// WouldRunNowLocal returns true if this job would run right now in the local timezone of the system, regardless of the
+// location of any other times. Always returns false for jobs that run at a fixed interval with Every.
+func (job Job) WouldRunNowLocal() bool {
+	return job.WouldRunNow(WithLocation(time.Local))
+}
+
+// WouldRunNowInTZ returns true if this job would run right now in the given timezone. Always returns false for jobs
+// that run at a fixed interval with Every, as those depend on when the tab was started.
 func (job Job) WouldRunNowInTZ(tz *time.Location) bool {
-	if job.Pattern == "* * * * *" {
-		return true
+	return job.WouldRunNow(WithLocation(tz))
+}
+
+// matchIn returns true if this job matches the given time in any of the job's locations, or in the given location if
+// the job doesn't have any
+func (job Job) matchIn(clock time.Time, loc *time.Location) bool {
+	if len(job.Locations) == 0 {
+		return job.matchAt(clock.In(loc))
+	}
+	for _, location := range job.Locations {
+		if job.matchAt(clock.In(location)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAt returns true if any of this job's patterns match the given time, in the location of the time. This is the
+// single path used to evaluate jobs against a time. Always returns false for invalid patterns and for jobs that run at
+// a fixed interval.
+func (job Job) matchAt(clock time.Time) bool {
+	if job.every() > 0 {
+		return false
+	}
+	if job.Window != nil && !job.Window.Contains(clock) {
+		return false
+	}
+	if job.At != nil {
+		target, ok := job.At(midnight(clock))
+		return ok && target.Truncate(time.Minute).Equal(clock.Truncate(time.Minute))
+	}
+	for _, pattern := range job.parse() {
+		if pattern.matches(clock) {
+			return true
+		}
+	}
+	return false
+}
+
+// Fields returns each component of this job's pattern as it was interpreted, with named values converted to their
+// numerical values. For jobs with multiple patterns, the first pattern is returned. Empty strings are returned if the
+// pattern is invalid, if the job runs at a fixed interval with Every, or if the job uses At.
+func (job Job) Fields() (minute, hour, dayOfMonth, month, dayOfWeek string) {
+	if job.every() > 0 || job.At != nil || job.Validate() != nil {
+		return
 	}
+	pattern := job.parse()[0].components
+	return pattern[0], pattern[1], pattern[2], pattern[3], pattern[4]
+}
 
-	if job.pattern == nil {
-		job.pattern = getRealPattern(job.Pattern)
+// every returns the fixed interval that this job runs at, either from Every or from a pattern such as @every 1h30m.
+// Returns 0 if the job does not run at a fixed interval.
+func (job Job) every() time.Duration {
+	if job.Every != 0 {
+		return job.Every
+	}
+	if strings.HasPrefix(job.Pattern, everyPrefix) {
+		d, _ := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(job.Pattern, everyPrefix)))
+		return d
 	}
+	return 0
+}
 
-	return patternDoesMatch(job.pattern, time.Now().In(tz))
+// patternStrings returns every pattern of this job
+func (job Job) patternStrings() []string {
+	patterns := []string{}
+	if job.Pattern != "" || len(job.Patterns) == 0 {
+		patterns = append(patterns, job.Pattern)
+	}
+	return append(patterns, job.Patterns...)
+}
+
+// parse returns the parsed patterns of this job. Returns nil if any pattern is not valid, which can happen for jobs
+// that weren't created through New.
+func (job Job) parse() []parsedPattern {
+	if job.parsed != nil {
+		return job.parsed
+	}
+	return job.parseWith(nil)
+}
+
+// parseWith parses the patterns of this job, ignoring any cached patterns, using the given source to choose random
+// values for any ~ components. If random is nil then the values are chosen once per process for the job's name and
+// pattern, so that parsing the same job again chooses the same values.
+func (job Job) parseWith(random *rand.Rand) []parsedPattern {
+	if job.every() > 0 || job.At != nil || job.Validate() != nil {
+		return nil
+	}
+
+	patterns := job.patternStrings()
+	parsed := make([]parsedPattern, len(patterns))
+	for i, pattern := range patterns {
+		key := job.Name + "\n" + pattern
+		if job.Seconds {
+			parsed[i].seconds, pattern, _ = splitSeconds(pattern)
+			parsed[i].seconds = resolveHashed(parsed[i].seconds, job.Name, secondComponent)
+			parsed[i].seconds = resolveRandom(parsed[i].seconds, secondComponent, random, key)
+		}
+		parsed[i].components = getRealPattern(pattern)
+		for c, component := range parsed[i].components {
+			parsed[i].components[c] = resolveRandom(resolveHashed(component, job.Name, c), c, random, key)
+		}
+	}
+	return parsed
+}
+
+// matches does this pattern match the specified time
+func (p parsedPattern) matches(clock time.Time) bool {
+	if p.seconds != "" && !isItTime(p.seconds, clock.Second()) {
+		return false
+	}
+	return patternDoesMatch(p.components, clock)
+}
+
+// MatchParsed returns true if the given parsed pattern matches the time, in the timezone of the time. The parsed
+// pattern is the 5 components returned by Job.Fields, in order. This avoids validating and parsing the pattern each
+// time, so it is faster than WouldRunNow when matching the same pattern repeatedly. Returns false if there are not 5
+// components or if the parsed pattern is malformed.
+func MatchParsed(parsed []string, t time.Time) bool {
+	if len(parsed) != 5 {
+		return false
+	}
+	for i, component := range parsed {
+		if !parsedComponentValid(component, i) {
+			return false
+		}
+	}
+	return patternDoesMatch(parsed, t)
+}
+
+// parsedComponentValid returns true if every element of the parsed component is a wildcard, value, range, or nearest
+// weekday, with an optional positive step, and every value is within the bounds of the component. Unlike validation of
+// a pattern, named values are not permitted and no error is described.
+func parsedComponentValid(component string, i int) bool {
+	if component == "*" {
+		return true
+	}
+
+	for _, element := range strings.Split(component, ",") {
+		if idx := strings.IndexRune(element, '/'); idx >= 0 {
+			if step, err := strconv.Atoi(element[idx+1:]); err != nil || step <= 0 {
+				return false
+			}
+			element = element[:idx]
+		}
+		if element == "*" || (i == 2 && nearestWeekdayPattern.MatchString(element)) {
+			continue
+		}
+
+		values := strings.Split(element, "-")
+		if len(values) > 2 {
+			return false
+		}
+		bounds := make([]int, len(values))
+		for j, value := range values {
+			v, err := strconv.Atoi(value)
+			if err != nil || !validateDateComponent(v, i) {
+				return false
+			}
+			bounds[j] = v
+		}
+		// Only day of week ranges may wrap around
+		if len(bounds) == 2 && bounds[0] > bounds[1] && i != 4 {
+			return false
+		}
+	}
+	return true
 }
 
 // patternDoesMatch does the given pattern match the specified time
 func patternDoesMatch(pattern []string, clock time.Time) bool {
-	minute := pattern[0]
-	hour := pattern[1]
+	minuteMatch := isItTime(pattern[0], clock.Minute())
+	hourMatch := isItTime(pattern[1], clock.Hour())
+	monthMatch := isItTime(pattern[3], int(clock.Month()))
+
+	return (minuteMatch && hourMatch && monthMatch) && dateDoesMatch(pattern, clock)
+}
+
+// dateDoesMatch does the day of month and day of week components of the given pattern match the specified time
+func dateDoesMatch(pattern []string, clock time.Time) bool {
+	dayOfMonthMatch, dayOfWeekMatch, either := dateMatchDetail(pattern, clock)
+	if either {
+		return dayOfMonthMatch || dayOfWeekMatch
+	}
+	return dayOfMonthMatch && dayOfWeekMatch
+}
+
+// dateMatchDetail returns if the day of month and day of week components match the current time, and if either is
+// enough for the date to match rather than both
+func dateMatchDetail(pattern []string, clock time.Time) (dayOfMonthMatch, dayOfWeekMatch, either bool) {
 	dayOfMonth := pattern[2]
-	month := pattern[3]
 	dayOfWeek := pattern[4]
 
-	minuteMatch := isItTime(minute, clock.Minute())
-	hourMatch := isItTime(hour, clock.Hour())
-	dayOfMonthMatch := isItTime(dayOfMonth, clock.Day())
-	monthMatch := isItTime(month, int(clock.Month()))
-	dayOfWeekMatch := isItTime(dayOfWeek, int(clock.Weekday()))
+	dayOfMonthMatch = dayOfMonthMatches(dayOfMonth, clock)
+	dayOfWeekMatch = isItTime(dayOfWeek, int(clock.Weekday()))
 
 	dowIsStar := dayOfWeek == "*"
 	domIsStar := dayOfMonth == "*"
 
-	var dateOfMatch bool
 	// From the spec:
 	//
 	//   if either the month or day of month is specified as an element or list, and the day of week is also specified
@@ -198,40 +1213,154 @@ func patternDoesMatch(pattern []string, clock time.Time) bool {
 	// OR-d. If either or both the day-of-week or day-of-month are wildcards, those two values are AND-d.
 	//
 	// To quote the SysV cron source "this routine is hard to understand"
-	if !dowIsStar && !domIsStar {
-		dateOfMatch = dayOfMonthMatch || dayOfWeekMatch
-	} else {
-		dateOfMatch = dayOfMonthMatch && dayOfWeekMatch
+	return dayOfMonthMatch, dayOfWeekMatch, !dowIsStar && !domIsStar
+}
+
+// dayOfMonthMatches does the given day of month component match the date of the current time. Unlike other components
+// the day of month may depend on the calendar, such as 15W for the weekday nearest the 15th.
+func dayOfMonthMatches(dayOfMonth string, clock time.Time) bool {
+	if nearestWeekdayPattern.MatchString(dayOfMonth) {
+		return clock.Day() == nearestWeekday(dayOfMonth, clock)
 	}
+	return isItTime(dayOfMonth, clock.Day())
+}
 
-	return (minuteMatch && hourMatch && monthMatch) && dateOfMatch
+// nearestWeekday returns the day of the month of the current time that the nearest weekday component, such as 15W or
+// LW, refers to. The nearest weekday never crosses into another month. Returns -1 if the day is not in the month.
+func nearestWeekday(dayOfMonth string, clock time.Time) int {
+	lastDay := time.Date(clock.Year(), clock.Month()+1, 0, 0, 0, 0, 0, clock.Location()).Day()
+
+	day := lastDay
+	if target := strings.TrimSuffix(strings.ToUpper(dayOfMonth), "W"); target != "L" {
+		day, _ = strconv.Atoi(target)
+	}
+	if day > lastDay {
+		return -1
+	}
+
+	switch time.Date(clock.Year(), clock.Month(), day, 0, 0, 0, 0, clock.Location()).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		if day == lastDay {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
 }
 
+// isItTime does the given pattern component match the current value. Components are a comma-separated list of
+// elements, where each element is a value, range, or expression.
 func isItTime(dateComponent string, currentValue int) bool {
+	if dateComponent == "*" {
+		return true
+	}
+
+	for _, element := range strings.Split(dateComponent, ",") {
+		if elementMatches(element, currentValue) {
+			return true
+		}
+	}
+	return false
+}
+
+func elementMatches(element string, currentValue int) bool {
 	// We don't validate any of the values here since we do that when the tab is created
-	if strings.ContainsRune(dateComponent, '/') {
-		divideBy, _ := strconv.Atoi(strings.Split(dateComponent, "/")[1])
-		return currentValue%divideBy == 0
-	} else if strings.ContainsRune(dateComponent, '-') {
-		parts := strings.Split(dateComponent, "-")
+	step := 0
+	if idx := strings.IndexRune(element, '/'); idx >= 0 {
+		step, _ = strconv.Atoi(element[idx+1:])
+		if step <= 0 {
+			return false
+		}
+		element = element[:idx]
+	}
+
+	if element == "*" {
+		return step == 0 || currentValue%step == 0
+	}
+
+	if strings.ContainsRune(element, '-') {
+		parts := strings.Split(element, "-")
 		start, _ := strconv.Atoi(parts[0])
 		end, _ := strconv.Atoi(parts[1])
-		return currentValue >= start && currentValue <= end
-	} else if strings.ContainsRune(dateComponent, ',') {
-		parts := strings.Split(dateComponent, ",")
-		for _, part := range parts {
-			value, _ := strconv.Atoi(part)
-			if currentValue == value {
-				return true
-			}
+		if start > end {
+			// Ranges that wrap around, such as FRI-MON, can't have a step
+			return currentValue >= start || currentValue <= end
 		}
-		return false
+		if currentValue < start || currentValue > end {
+			return false
+		}
+		return step == 0 || (currentValue-start)%step == 0
 	}
 
-	return dateComponent == toString(currentValue) || dateComponent == "*"
+	// Compare numerically so that values with leading zeros, such as 05, still match
+	value, _ := strconv.Atoi(element)
+	if step > 0 {
+		// A step applied to a single value runs from that value until the end of the component
+		return currentValue >= value && (currentValue-value)%step == 0
+	}
+	return value == currentValue
+}
+
+// withMiddleware returns the given method of the job wrapped by each middleware of the tab, where the first
+// middleware is the outermost
+func (s *Tab) withMiddleware(job Job, exec func()) func() {
+	for i := len(s.Middleware) - 1; i >= 0; i-- {
+		middleware := s.Middleware[i]
+		next := exec
+		exec = func() {
+			middleware(job, next)
+		}
+	}
+	return exec
 }
 
 func (s *Tab) runJob(job Job) {
+	s.recordRun(job.Name, s.getClock().Now())
+	s.markJobRunning(job.Name, true)
+	defer s.markJobRunning(job.Name, false)
+
+	backoff := job.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		recovered, ok := s.runAttempt(job)
+		if ok {
+			return
+		}
+		if attempt >= job.Retries {
+			if job.SkipAfterError {
+				s.setJobFailed(job.Name, true)
+			}
+			if recovered != nil && s.RepanicOnJobPanic {
+				panic(recovered)
+			}
+			return
+		}
+
+		log.PWarn("Retrying scheduled job", map[string]interface{}{
+			"name":    job.Name,
+			"attempt": attempt + 1,
+			"retries": job.Retries,
+			"backoff": backoff.String(),
+		})
+		if backoff > 0 {
+			if !s.sleepUnlessStopped(backoff) {
+				log.PDebug("Tab stopped before job was retried", map[string]interface{}{
+					"name": job.Name,
+				})
+				return
+			}
+			backoff *= 2
+		}
+	}
+}
+
+// runAttempt executes the job once. Returns true if the job did not panic or return an error, otherwise the value the
+// job panicked with, if any, is returned.
+func (s *Tab) runAttempt(job Job) (recovered interface{}, ok bool) {
 	start := time.Now()
 	log.PDebug("Starting scheduled job", map[string]interface{}{
 		"name": job.Name,
@@ -242,17 +1371,58 @@ func (s *Tab) runJob(job Job) {
 				"name":  job.Name,
 				"error": fmt.Sprintf("%s", r),
 			})
-			log.Debug("%s", debug.Stack())
+			log.PDebug("Job panic stack trace", map[string]interface{}{
+				"name":  job.Name,
+				"stack": string(debug.Stack()),
+			})
+			s.recordPanic(job.Name)
+			s.emit(EventPanic, job, time.Since(start), fmt.Sprintf("%s", r))
+			recovered = r
+			ok = false
 		}
 	}()
-	job.Exec()
-	elapsed := time.Since(start)
-	log.PDebug("Scheduled job finished", map[string]interface{}{
-		"name":    job.Name,
-		"elapsed": elapsed.String(),
-	})
-}
+	if s.OnJobStart != nil {
+		s.OnJobStart(job, false)
+	}
+	s.emit(EventStart, job, 0, "")
 
-func toString(i int) string {
-	return fmt.Sprintf("%d", i)
+	var err error
+	exec := job.Exec
+	if job.ExecAt != nil {
+		exec = func() {
+			job.ExecAt(job.scheduledFor)
+		}
+	}
+	if job.ExecE != nil {
+		exec = func() {
+			err = job.ExecE()
+		}
+	}
+	s.withMiddleware(job, exec)()
+	elapsed := time.Since(start)
+	if err != nil {
+		log.PError("Scheduled job returned an error", map[string]interface{}{
+			"name":    job.Name,
+			"error":   err.Error(),
+			"elapsed": elapsed.String(),
+		})
+		s.emit(EventError, job, elapsed, err.Error())
+	} else {
+		s.emit(EventFinish, job, elapsed, "")
+		log.PDebug("Scheduled job finished", map[string]interface{}{
+			"name":    job.Name,
+			"elapsed": elapsed.String(),
+		})
+	}
+	if s.SlowThreshold > 0 && elapsed > s.SlowThreshold {
+		log.PWarn("Scheduled job was slow", map[string]interface{}{
+			"name":      job.Name,
+			"elapsed":   elapsed.String(),
+			"threshold": s.SlowThreshold.String(),
+		})
+		if s.OnSlow != nil {
+			s.OnSlow(job, elapsed)
+		}
+	}
+	return nil, err == nil
 }
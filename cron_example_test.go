@@ -1,6 +1,11 @@
 package cron_test
 
-import "github.com/ecnepsnai/cron"
+import (
+	"fmt"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
 
 func ExampleNew() {
 	schedule, _ := cron.New([]cron.Job{
@@ -29,3 +34,22 @@ func ExampleNew() {
 	// This will start the cron at (or as close to as possible) 0 seconds of the next minute
 	go schedule.Start()
 }
+
+func ExampleSimulate() {
+	job := cron.Job{
+		Pattern: "0 9 * * MON-WED,FRI",
+		Holiday: cron.Holidays(time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)),
+	}
+
+	// Assert in a test that the job runs on the expected days
+	from := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2021, time.January, 8, 23, 59, 0, 0, time.UTC)
+	for _, run := range cron.Simulate(job, from, until) {
+		fmt.Println(run.Format("Mon Jan 2 15:04"))
+	}
+	// Output:
+	// Mon Jan 4 09:00
+	// Tue Jan 5 09:00
+	// Wed Jan 6 09:00
+	// Fri Jan 8 09:00
+}
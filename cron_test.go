@@ -1,6 +1,13 @@
 package cron_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -73,3 +80,832 @@ func TestCronNewInvalid(t *testing.T) {
 		t.Fatalf("Tab returned with invalid job")
 	}
 }
+
+// waitFor polls check until it returns true, failing the test if it doesn't within one second
+func waitFor(t *testing.T, what string, check func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(1 * time.Second)
+	for !check() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(1 * time.Millisecond)
+	}
+}
+
+func TestCronRunningStatus(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:    "SlowJob",
+			Pattern: "* * * * *",
+			Exec: func() {
+				<-release
+			},
+		},
+	})
+	tab.Interval = 1 * time.Millisecond
+
+	if tab.Running() {
+		t.Fatalf("Tab should not be running before it is started")
+	}
+	if tab.IsRunning("SlowJob") {
+		t.Fatalf("Job should not be running before the tab is started")
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		tab.ForceStart()
+		close(finished)
+	}()
+
+	waitFor(t, "tab to start", tab.Running)
+	waitFor(t, "job to start", func() bool { return tab.IsRunning("SlowJob") })
+	if tab.IsRunning("UnknownJob") {
+		t.Fatalf("Unknown job should not be running")
+	}
+
+	tab.StopSoon()
+	<-finished
+	if tab.Running() {
+		t.Fatalf("Tab should not be running after it stopped")
+	}
+
+	close(release)
+	waitFor(t, "job to finish", func() bool { return !tab.IsRunning("SlowJob") })
+}
+
+func TestCronTotalRuns(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:    "CountedJob",
+			Pattern: "* * * * *",
+			Exec:    func() {},
+		},
+	})
+	tab.Interval = 1 * time.Millisecond
+
+	if tab.TotalRuns() != 0 {
+		t.Fatalf("Unexpected total runs before starting: %d", tab.TotalRuns())
+	}
+
+	go tab.ForceStart()
+	waitFor(t, "job to run", func() bool { return tab.TotalRuns() > 0 })
+	tab.StopSoon()
+}
+
+func TestCronResetStats(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:    "CountedJob",
+			Pattern: "* * * * *",
+			Exec:    func() {},
+		},
+	})
+
+	if _, ok := tab.LastRun("CountedJob"); ok {
+		t.Fatalf("Unexpected last run before starting")
+	}
+
+	stop := tab.Run()
+	waitFor(t, "job to run", func() bool { return tab.TotalRuns() > 0 })
+	stop()
+
+	if runs := tab.JobRuns("CountedJob"); runs != 1 {
+		t.Fatalf("Unexpected job runs. Expected %d got %d", 1, runs)
+	}
+	if _, ok := tab.LastRun("CountedJob"); !ok {
+		t.Fatalf("No last run for job that ran")
+	}
+
+	tab.ResetStats()
+	if tab.TotalRuns() != 0 {
+		t.Errorf("Unexpected total runs after reset: %d", tab.TotalRuns())
+	}
+	if runs := tab.JobRuns("CountedJob"); runs != 0 {
+		t.Errorf("Unexpected job runs after reset: %d", runs)
+	}
+	if _, ok := tab.LastRun("CountedJob"); ok {
+		t.Errorf("Unexpected last run after reset")
+	}
+}
+
+func TestCronSlowThreshold(t *testing.T) {
+	t.Parallel()
+
+	slow := make(chan time.Duration, 2)
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:    "SlowJob",
+			Pattern: "* * * * *",
+			Exec: func() {
+				time.Sleep(20 * time.Millisecond)
+			},
+		},
+		{
+			Name:    "FastJob",
+			Pattern: "* * * * *",
+			Exec:    func() {},
+		},
+	})
+	tab.SlowThreshold = 10 * time.Millisecond
+	tab.OnSlow = func(job cron.Job, elapsed time.Duration) {
+		if job.Name != "SlowJob" {
+			t.Errorf("Unexpected slow job '%s'", job.Name)
+		}
+		slow <- elapsed
+	}
+
+	stop := tab.Run()
+	waitFor(t, "jobs to run", func() bool { return tab.TotalRuns() == 2 })
+	stop()
+
+	select {
+	case elapsed := <-slow:
+		if elapsed < tab.SlowThreshold {
+			t.Errorf("Unexpected elapsed time for slow job %s", elapsed)
+		}
+	default:
+		t.Fatalf("OnSlow not called for slow job")
+	}
+}
+
+func TestCronSequential(t *testing.T) {
+	t.Parallel()
+
+	var lock sync.Mutex
+	order := []string{}
+	var active atomic.Int32
+	var finished atomic.Int32
+	exec := func(name string, panics bool) func() {
+		return func() {
+			defer finished.Add(1)
+			if active.Add(1) > 1 {
+				t.Errorf("Job '%s' overlapped with another job", name)
+			}
+			defer active.Add(-1)
+			time.Sleep(5 * time.Millisecond)
+			lock.Lock()
+			order = append(order, name)
+			lock.Unlock()
+			if panics {
+				panic("oops")
+			}
+		}
+	}
+
+	tab, _ := cron.New([]cron.Job{
+		{Name: "First", Pattern: "* * * * *", Exec: exec("First", false)},
+		{Name: "Second", Pattern: "* * * * *", Exec: exec("Second", true)},
+		{Name: "Third", Pattern: "* * * * *", Exec: exec("Third", false)},
+	})
+	tab.Sequential = true
+
+	stop := tab.Run()
+	waitFor(t, "jobs to run", func() bool { return finished.Load() == 3 })
+	stop()
+
+	lock.Lock()
+	defer lock.Unlock()
+	if strings.Join(order, ",") != "First,Second,Third" {
+		t.Errorf("Unexpected order of jobs: %v", order)
+	}
+}
+
+func TestCronJobOrder(t *testing.T) {
+	t.Parallel()
+
+	var lock sync.Mutex
+	executed := []string{}
+	started := []string{}
+	jobs := []cron.Job{}
+	expected := []string{}
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("Job%d", i)
+		expected = append(expected, name)
+		jobs = append(jobs, cron.Job{
+			Name:    name,
+			Pattern: "* * * * *",
+			Exec: func() {
+				lock.Lock()
+				executed = append(executed, name)
+				lock.Unlock()
+			},
+		})
+	}
+	tab, _ := cron.New(jobs)
+	tab.Sequential = true
+	tab.OnJobStart = func(job cron.Job, dryRun bool) {
+		lock.Lock()
+		started = append(started, job.Name)
+		lock.Unlock()
+	}
+
+	stop := tab.Run()
+	waitFor(t, "jobs to run", func() bool { return tab.TotalRuns() == 10 })
+	stop()
+
+	lock.Lock()
+	defer lock.Unlock()
+	if strings.Join(started, ",") != strings.Join(expected, ",") {
+		t.Errorf("Jobs not started in order: %v", started)
+	}
+	if strings.Join(executed, ",") != strings.Join(expected, ",") {
+		t.Errorf("Jobs not executed in order: %v", executed)
+	}
+}
+
+func TestCronDueAt(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := cron.New([]cron.Job{
+		{Name: "Midnight", Pattern: "0 0 * * *"},
+		{Name: "EveryMinute", Pattern: "* * * * *"},
+		{Name: "Noon", Pattern: "0 12 * * *"},
+		{Name: "Hourly", Pattern: "0 * * * *"},
+		{Name: "Every", Every: time.Minute},
+	})
+	tab.TZ = time.UTC
+
+	names := func(jobs []cron.Job) string {
+		n := []string{}
+		for _, job := range jobs {
+			n = append(n, job.Name)
+		}
+		return strings.Join(n, ",")
+	}
+
+	if due := names(tab.DueAt(time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC))); due != "Midnight,EveryMinute,Hourly" {
+		t.Errorf("Unexpected jobs due at midnight: %s", due)
+	}
+	if due := names(tab.DueAt(time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC))); due != "EveryMinute,Noon,Hourly" {
+		t.Errorf("Unexpected jobs due at noon: %s", due)
+	}
+	// Times are evaluated in the timezone of the tab
+	if due := names(tab.DueAt(time.Date(2021, time.January, 1, 4, 0, 0, 0, time.FixedZone("PST", -8*60*60)))); due != "EveryMinute,Noon,Hourly" {
+		t.Errorf("Unexpected jobs due at noon UTC: %s", due)
+	}
+	if due := names(tab.DueNow()); !strings.Contains(due, "EveryMinute") {
+		t.Errorf("Unexpected jobs due now: %s", due)
+	}
+}
+
+func TestCronJitter(t *testing.T) {
+	t.Parallel()
+
+	fired := make(chan time.Time, 1)
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:    "Jittered",
+			Pattern: "* * * * *",
+			Exec: func() {
+				fired <- time.Now()
+			},
+		},
+	})
+	tab.Jitter = 50 * time.Millisecond
+
+	start := time.Now()
+	stop := tab.Run()
+	defer stop()
+	select {
+	case at := <-fired:
+		if delay := at.Sub(start); delay > tab.Jitter+250*time.Millisecond {
+			t.Errorf("Job started %s after the tab, outside of the jitter window", delay)
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatalf("Jittered job never ran")
+	}
+}
+
+func TestCronJitterStop(t *testing.T) {
+	t.Parallel()
+
+	var ran atomic.Bool
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:    "Jittered",
+			Pattern: "* * * * *",
+			Exec: func() {
+				ran.Store(true)
+			},
+		},
+	})
+	tab.Jitter = 24 * time.Hour
+
+	stop := tab.Run()
+	waitFor(t, "tab to start", tab.Running)
+
+	stopped := make(chan struct{})
+	go func() {
+		stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(1 * time.Second):
+		t.Fatalf("Stopping the tab did not interrupt the jittered job")
+	}
+	if ran.Load() {
+		t.Errorf("Jittered job ran after the tab stopped")
+	}
+}
+
+func TestCronMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var lock sync.Mutex
+	calls := []string{}
+	record := func(call string) {
+		lock.Lock()
+		calls = append(calls, call)
+		lock.Unlock()
+	}
+
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:    "Wrapped",
+			Pattern: "* * * * *",
+			Exec: func() {
+				record("job")
+			},
+		},
+		{
+			Name:    "Panics",
+			Pattern: "* * * * *",
+			Exec: func() {
+				panic("oops")
+			},
+		},
+	})
+	tab.Sequential = true
+	tab.Middleware = []func(job cron.Job, next func()){
+		func(job cron.Job, next func()) {
+			record("outer before " + job.Name)
+			next()
+			record("outer after " + job.Name)
+		},
+		func(job cron.Job, next func()) {
+			record("inner before " + job.Name)
+			next()
+			record("inner after " + job.Name)
+		},
+	}
+
+	stop := tab.Run()
+	waitFor(t, "jobs to run", func() bool { return tab.TotalRuns() == 2 })
+	stop()
+
+	lock.Lock()
+	defer lock.Unlock()
+	expected := "outer before Wrapped,inner before Wrapped,job,inner after Wrapped,outer after Wrapped,outer before Panics,inner before Panics"
+	if strings.Join(calls, ",") != expected {
+		t.Errorf("Unexpected middleware calls: %v", calls)
+	}
+}
+
+func TestCronRunOnStartIfDue(t *testing.T) {
+	t.Parallel()
+
+	var ran atomic.Bool
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:    "EveryMinute",
+			Pattern: "* * * * *",
+			Exec: func() {
+				ran.Store(true)
+			},
+		},
+	})
+	tab.RunOnStartIfDue = true
+
+	go tab.Start()
+	waitFor(t, "job to run", ran.Load)
+	tab.StopSoon()
+}
+
+func TestCronRun(t *testing.T) {
+	t.Parallel()
+
+	var finished atomic.Bool
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:    "RunJob",
+			Pattern: "* * * * *",
+			Exec: func() {
+				time.Sleep(10 * time.Millisecond)
+				finished.Store(true)
+			},
+		},
+	})
+	tab.Interval = 1 * time.Hour
+
+	stop := tab.Run()
+	waitFor(t, "job to start", func() bool { return tab.IsRunning("RunJob") })
+
+	stop()
+	if tab.Running() {
+		t.Errorf("Tab should not be running after stop")
+	}
+	if !finished.Load() {
+		t.Errorf("Stop returned before in-flight job finished")
+	}
+
+	// Calling stop again should not block or panic
+	stop()
+}
+
+func TestCronWait(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	var finished atomic.Bool
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:    "SlowJob",
+			Pattern: "* * * * *",
+			Exec: func() {
+				<-release
+				finished.Store(true)
+			},
+		},
+	})
+
+	tab.Interval = 1 * time.Millisecond
+
+	// Waiting on a tab that was never started should not block
+	tab.Wait()
+
+	go tab.ForceStart()
+	waitFor(t, "job to start", func() bool { return tab.IsRunning("SlowJob") })
+	tab.StopSoon()
+
+	waited := make(chan struct{})
+	go func() {
+		tab.Wait()
+		close(waited)
+	}()
+
+	waitFor(t, "tab to stop", func() bool { return !tab.Running() })
+	select {
+	case <-waited:
+		t.Fatalf("Wait returned before job finished")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(release)
+	<-waited
+	if !finished.Load() {
+		t.Errorf("Wait returned before job finished")
+	}
+}
+
+func TestCronJobDescription(t *testing.T) {
+	t.Parallel()
+
+	tab, err := cron.New([]cron.Job{
+		{
+			Name:        "Backup",
+			Pattern:     "0 2 * * *",
+			Description: "Nightly database backup",
+			Exec:        func() {},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error creating tab: %s", err.Error())
+	}
+
+	job := tab.Jobs[0]
+	if job.Description != "Nightly database backup" {
+		t.Errorf("Description not preserved through New. Got '%s'", job.Description)
+	}
+
+	if s := job.String(); s != "Backup (0 2 * * *): Nightly database backup" {
+		t.Errorf("Unexpected job string '%s'", s)
+	}
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		t.Fatalf("Error marshalling job: %s", err.Error())
+	}
+	if s := string(data); s != `{"pattern":"0 2 * * *","name":"Backup","description":"Nightly database backup"}` {
+		t.Errorf("Unexpected job JSON '%s'", s)
+	}
+}
+
+func TestCronNewDuplicateNames(t *testing.T) {
+	t.Parallel()
+
+	tab, err := cron.New([]cron.Job{
+		{
+			Name:    "SameName",
+			Pattern: "* * * * *",
+			Exec:    func() {},
+		},
+		{
+			Name:    "SameName",
+			Pattern: "0 * * * *",
+			Exec:    func() {},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error seen when creating crontab with duplicate names")
+	}
+	if tab != nil {
+		t.Fatalf("Tab returned with duplicate names")
+	}
+
+	_, err = cron.New([]cron.Job{
+		{
+			Pattern: "* * * * *",
+			Exec:    func() {},
+		},
+		{
+			Pattern: "0 * * * *",
+			Exec:    func() {},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error creating crontab with multiple unnamed jobs: %s", err.Error())
+	}
+}
+
+func TestCronEvery(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+	tab, err := cron.New([]cron.Job{
+		{
+			Name:  "EveryJob",
+			Every: 50 * time.Millisecond,
+			Exec: func() {
+				runs.Add(1)
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error creating tab: %s", err.Error())
+	}
+	tab.Interval = 1 * time.Millisecond
+
+	stop := tab.Run()
+	time.Sleep(25 * time.Millisecond)
+	if runs.Load() != 0 {
+		t.Errorf("Job ran before its first interval elapsed")
+	}
+	time.Sleep(155 * time.Millisecond)
+	stop()
+
+	if r := runs.Load(); r < 2 || r > 4 {
+		t.Errorf("Unexpected number of runs for fixed interval job. Expected 3 got %d", r)
+	}
+}
+
+func TestCronEveryInvalid(t *testing.T) {
+	t.Parallel()
+
+	if _, err := cron.New([]cron.Job{{Name: "Negative", Every: -1 * time.Second, Exec: func() {}}}); err == nil {
+		t.Errorf("No error seen for job with negative interval")
+	}
+}
+
+func TestCronRepanic(t *testing.T) {
+	t.Parallel()
+
+	// The panic will crash the process, so the tab is started in a child process
+	if os.Getenv("CRON_TEST_REPANIC") == "1" {
+		tab, _ := cron.New([]cron.Job{
+			{
+				Name:    "RepanicCron",
+				Pattern: "* * * * *",
+				Exec: func() {
+					panic("(intentional repanic)")
+				},
+			},
+		})
+		tab.RepanicOnJobPanic = true
+		tab.Interval = 1 * time.Millisecond
+		tab.ForceStart()
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestCronRepanic$")
+	cmd.Env = append(os.Environ(), "CRON_TEST_REPANIC=1")
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("Process did not exit with an error after job panic")
+	}
+	if !strings.Contains(string(output), "(intentional repanic)") {
+		t.Errorf("Process output did not contain panic message: %s", output)
+	}
+}
+
+func TestCronPanicContinues(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:  "PanicEvery",
+			Every: 5 * time.Millisecond,
+			Exec: func() {
+				runs.Add(1)
+				panic("(intentional panic)")
+			},
+		},
+	})
+	tab.Interval = 1 * time.Millisecond
+
+	stop := tab.Run()
+	waitFor(t, "job to panic more than once", func() bool { return runs.Load() > 1 })
+	if !tab.Running() {
+		t.Errorf("Tab should still be running after a job panic")
+	}
+	stop()
+}
+
+func TestCronClone(t *testing.T) {
+	t.Parallel()
+
+	expireAfter := time.Now().AddDate(1, 0, 0)
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:     "Original",
+			Pattern:  "0 * * * *",
+			Patterns: []string{"30 * * * *"},
+			Exec:     func() {},
+		},
+	})
+	tab.Interval = 5 * time.Second
+	tab.ExpireAfter = &expireAfter
+
+	clone := tab.Clone()
+	if clone.Interval != tab.Interval {
+		t.Errorf("Interval not carried over to clone")
+	}
+	if clone.ExpireAfter == nil || !clone.ExpireAfter.Equal(expireAfter) {
+		t.Errorf("ExpireAfter not carried over to clone")
+	}
+	if clone.Jobs[0].String() != tab.Jobs[0].String() {
+		t.Errorf("Clone job does not match original job")
+	}
+
+	clone.Jobs[0].Name = "Clone"
+	clone.Jobs[0].Patterns[0] = "15 * * * *"
+	clone.Jobs = append(clone.Jobs, cron.Job{Name: "Added", Pattern: "* * * * *"})
+	clone.Interval = time.Minute
+	*clone.ExpireAfter = time.Now()
+
+	if tab.Jobs[0].Name != "Original" {
+		t.Errorf("Changing the clone job name changed the original")
+	}
+	if tab.Jobs[0].Patterns[0] != "30 * * * *" {
+		t.Errorf("Changing the clone job patterns changed the original")
+	}
+	if len(tab.Jobs) != 1 {
+		t.Errorf("Adding a job to the clone changed the original")
+	}
+	if tab.Interval != 5*time.Second {
+		t.Errorf("Changing the clone interval changed the original")
+	}
+	if !tab.ExpireAfter.Equal(expireAfter) {
+		t.Errorf("Changing the clone expiry changed the original")
+	}
+}
+
+func TestCronCloneLocations(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:      "Offices",
+			Pattern:   "0 9 * * *",
+			Locations: []*time.Location{time.UTC, time.Local},
+			Exec:      func() {},
+		},
+	})
+
+	clone := tab.Clone()
+	clone.Jobs[0].Locations[0] = time.FixedZone("Elsewhere", 3600)
+	if tab.Jobs[0].Locations[0] != time.UTC {
+		t.Errorf("Changing the clone locations changed the original")
+	}
+}
+
+func TestCronCloneWindow(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:    "Business",
+			Pattern: "* * * * *",
+			Window:  cron.BusinessHours(),
+			Exec:    func() {},
+		},
+	})
+
+	clone := tab.Clone()
+	clone.Jobs[0].Window.Start = time.Hour
+	clone.Jobs[0].Window.Weekdays[0] = time.Sunday
+	if tab.Jobs[0].Window.Start != 9*time.Hour {
+		t.Errorf("Changing the clone window changed the original")
+	}
+	if tab.Jobs[0].Window.Weekdays[0] != time.Monday {
+		t.Errorf("Changing the clone window weekdays changed the original")
+	}
+}
+
+func TestCronForceStartUntil(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:    "Job",
+			Pattern: "* * * * *",
+			Exec:    func() {},
+		},
+	})
+
+	done := make(chan struct{})
+	returned := make(chan struct{})
+	go func() {
+		tab.ForceStartUntil(done)
+		close(returned)
+	}()
+	waitFor(t, "tab to start", tab.Running)
+
+	close(done)
+	select {
+	case <-returned:
+	case <-time.After(1 * time.Second):
+		t.Fatalf("ForceStartUntil did not return after done was closed")
+	}
+	if tab.Running() {
+		t.Errorf("Tab still running after ForceStartUntil returned")
+	}
+}
+
+func TestCronRetries(t *testing.T) {
+	t.Parallel()
+
+	var lock sync.Mutex
+	events := map[string][]string{}
+	attempts := 0
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:         "FailsTwice",
+			Pattern:      "* * * * *",
+			Retries:      3,
+			RetryBackoff: 1 * time.Millisecond,
+			ExecE: func() error {
+				lock.Lock()
+				defer lock.Unlock()
+				attempts++
+				if attempts <= 2 {
+					return fmt.Errorf("attempt %d failed", attempts)
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "AlwaysPanics",
+			Pattern: "* * * * *",
+			Retries: 1,
+			Exec: func() {
+				panic("oops")
+			},
+		},
+	})
+	tab.Sequential = true
+	tab.EventSink = func(event cron.Event) {
+		lock.Lock()
+		defer lock.Unlock()
+		events[event.JobName] = append(events[event.JobName], string(event.Type))
+	}
+
+	stop := tab.Run()
+	waitFor(t, "jobs to run", func() bool { return tab.TotalRuns() == 2 })
+	stop()
+
+	lock.Lock()
+	defer lock.Unlock()
+	if attempts != 3 {
+		t.Errorf("Unexpected number of attempts. Expected %d got %d", 3, attempts)
+	}
+	expect := func(name string, expected string) {
+		if actual := strings.Join(events[name], ","); actual != expected {
+			t.Errorf("Unexpected events for %s. Expected '%s' got '%s'", name, expected, actual)
+		}
+	}
+	expect("FailsTwice", "start,error,start,error,start,finish")
+	expect("AlwaysPanics", "start,panic,start,panic")
+	if tab.JobRuns("FailsTwice") != 1 {
+		t.Errorf("Retries should not count as additional runs")
+	}
+}
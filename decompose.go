@@ -0,0 +1,166 @@
+package cron
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FieldKind describes the type of a single component of a pattern
+type FieldKind int
+
+const (
+	// FieldWildcard is a component that matches every value, *
+	FieldWildcard FieldKind = iota
+	// FieldValue is a component of a single numerical value, such as 5
+	FieldValue
+	// FieldRange is a component of an inclusive range of values, such as 1-5 or MON-FRI
+	FieldRange
+	// FieldList is a component of multiple comma separated elements, such as 1,15,30
+	FieldList
+	// FieldStep is a component of a wildcard, range, or single value with a step, such as */15, 0-30/10, or 7/30
+	FieldStep
+	// FieldName is a component of a single named value, such as JAN or MON
+	FieldName
+	// FieldSpecial is a component that uses any other syntax, such as H, ~, or 15W. Only Raw is populated.
+	FieldSpecial
+)
+
+// String returns the name of the kind, such as "wildcard" or "range"
+func (k FieldKind) String() string {
+	switch k {
+	case FieldWildcard:
+		return "wildcard"
+	case FieldValue:
+		return "value"
+	case FieldRange:
+		return "range"
+	case FieldList:
+		return "list"
+	case FieldStep:
+		return "step"
+	case FieldName:
+		return "name"
+	case FieldSpecial:
+		return "special"
+	}
+	return "unknown"
+}
+
+// Field describes a single component of a pattern. Which parameters are populated depends on the Kind.
+type Field struct {
+	// The type of component
+	Kind FieldKind
+	// The component as it was written in the pattern
+	Raw string
+	// The numerical value for FieldValue and FieldName components
+	Value int
+	// The name as it was written, for FieldName components
+	Name string
+	// The first value of the range for FieldRange and FieldStep components. For steps of a wildcard this is the lowest
+	// value of the field.
+	Start int
+	// The last value of the range for FieldRange and FieldStep components. For steps of a wildcard or of a single
+	// value this is the highest value of the field.
+	End int
+	// The step for FieldStep components
+	Step int
+	// Each element of FieldList components
+	Elements []Field
+}
+
+// Fields describes each component of a pattern
+type Fields struct {
+	Minute     Field
+	Hour       Field
+	DayOfMonth Field
+	Month      Field
+	DayOfWeek  Field
+}
+
+// Decompose validates the given pattern and returns a typed description of each of its 5 components, which is useful
+// for building tools that edit patterns. Named values are described with their numerical values. Patterns that include
+// seconds or run at a fixed interval are not supported.
+func Decompose(pattern string) (Fields, error) {
+	if err := validatePattern(pattern); err != nil {
+		return Fields{}, err
+	}
+
+	components := strings.Split(expandNickname(pattern), " ")
+	fields := make([]Field, len(components))
+	for i, component := range components {
+		fields[i] = decomposeComponent(component, i)
+	}
+	return Fields{
+		Minute:     fields[0],
+		Hour:       fields[1],
+		DayOfMonth: fields[2],
+		Month:      fields[3],
+		DayOfWeek:  fields[4],
+	}, nil
+}
+
+// decomposeComponent describes a single component. This assumes the component has already been validated.
+func decomposeComponent(component string, i int) Field {
+	if component == "*" {
+		return Field{Kind: FieldWildcard, Raw: component}
+	}
+	if component == randomComponent || hashedPattern.MatchString(component) || nearestWeekdayPattern.MatchString(component) {
+		return Field{Kind: FieldSpecial, Raw: component}
+	}
+
+	if strings.ContainsRune(component, ',') {
+		field := Field{Kind: FieldList, Raw: component}
+		for _, element := range strings.Split(component, ",") {
+			field.Elements = append(field.Elements, decomposeComponent(element, i))
+		}
+		return field
+	}
+
+	if before, after, ok := strings.Cut(component, "/"); ok {
+		field := Field{Kind: FieldStep, Raw: component}
+		field.Step, _ = strconv.Atoi(after)
+		if before == "*" {
+			field.Start, field.End = fieldBounds(i)
+		} else if !strings.ContainsRune(before, '-') {
+			field.Start = decomposeValue(before, i)
+			_, field.End = fieldBounds(i)
+		} else {
+			start, end, _ := strings.Cut(before, "-")
+			field.Start = decomposeValue(start, i)
+			field.End = decomposeValue(end, i)
+		}
+		return field
+	}
+
+	if start, end, ok := strings.Cut(component, "-"); ok {
+		return Field{Kind: FieldRange, Raw: component, Start: decomposeValue(start, i), End: decomposeValue(end, i)}
+	}
+
+	if namedElementPattern.MatchString(component) {
+		return Field{Kind: FieldName, Raw: component, Name: component, Value: decomposeValue(component, i)}
+	}
+
+	return Field{Kind: FieldValue, Raw: component, Value: decomposeValue(component, i)}
+}
+
+// decomposeValue returns the numerical value of a single value or name in the component
+func decomposeValue(value string, i int) int {
+	switch i {
+	case 1:
+		value = replaceMeridiem(value)
+	case 3:
+		value = replaceNames(strings.ToUpper(value), monthMap)
+	case 4:
+		value = replaceNames(strings.ToUpper(value), weekdayMap)
+	}
+	v, _ := strconv.Atoi(value)
+	return v
+}
+
+// fieldBounds returns the lowest and highest values allowed for the component
+func fieldBounds(i int) (low int, high int) {
+	if i == 2 {
+		return 1, 31
+	}
+	return hashBounds(i)
+}
@@ -0,0 +1,88 @@
+package cron_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestDecompose(t *testing.T) {
+	t.Parallel()
+
+	fields, err := cron.Decompose("*/15 9-17 1,15,L-2W JAN mon-fri")
+	if err == nil {
+		t.Fatalf("No error seen for invalid pattern")
+	}
+
+	fields, err = cron.Decompose("*/15 9-17 1,15 JAN mon-fri")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	expect := func(name string, actual cron.Field, expected cron.Field) {
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Unexpected %s field. Expected %+v got %+v", name, expected, actual)
+		}
+	}
+
+	expect("minute", fields.Minute, cron.Field{Kind: cron.FieldStep, Raw: "*/15", Start: 0, End: 59, Step: 15})
+	expect("hour", fields.Hour, cron.Field{Kind: cron.FieldRange, Raw: "9-17", Start: 9, End: 17})
+	expect("day of month", fields.DayOfMonth, cron.Field{Kind: cron.FieldList, Raw: "1,15", Elements: []cron.Field{
+		{Kind: cron.FieldValue, Raw: "1", Value: 1},
+		{Kind: cron.FieldValue, Raw: "15", Value: 15},
+	}})
+	expect("month", fields.Month, cron.Field{Kind: cron.FieldName, Raw: "JAN", Name: "JAN", Value: 1})
+	expect("day of week", fields.DayOfWeek, cron.Field{Kind: cron.FieldRange, Raw: "mon-fri", Start: 1, End: 5})
+}
+
+func TestDecomposeKinds(t *testing.T) {
+	t.Parallel()
+
+	expect := func(pattern string, kind cron.FieldKind) {
+		fields, err := cron.Decompose(pattern)
+		if err != nil {
+			t.Errorf("Unexpected error decomposing '%s': %s", pattern, err.Error())
+			return
+		}
+		if fields.Minute.Kind != kind {
+			t.Errorf("Unexpected kind for '%s'. Expected %s got %s", pattern, kind, fields.Minute.Kind)
+		}
+	}
+
+	expect("* * * * *", cron.FieldWildcard)
+	expect("5 * * * *", cron.FieldValue)
+	expect("1-5 * * * *", cron.FieldRange)
+	expect("1,5 * * * *", cron.FieldList)
+	expect("0-30/10 * * * *", cron.FieldStep)
+	expect("7/30 * * * *", cron.FieldStep)
+	expect("H * * * *", cron.FieldSpecial)
+	expect("~ * * * *", cron.FieldSpecial)
+
+	fields, _ := cron.Decompose("0 0 15W * SUN")
+	if fields.DayOfMonth.Kind != cron.FieldSpecial || fields.DayOfMonth.Raw != "15W" {
+		t.Errorf("Unexpected field for nearest weekday: %+v", fields.DayOfMonth)
+	}
+	if fields.DayOfWeek.Kind != cron.FieldName || fields.DayOfWeek.Value != 0 {
+		t.Errorf("Unexpected field for named weekday: %+v", fields.DayOfWeek)
+	}
+	fields, _ = cron.Decompose("7/30 * * * *")
+	if fields.Minute.Start != 7 || fields.Minute.End != 59 || fields.Minute.Step != 30 {
+		t.Errorf("Unexpected field for step from a value: %+v", fields.Minute)
+	}
+	if cron.FieldStep.String() != "step" {
+		t.Errorf("Unexpected kind name '%s'", cron.FieldStep.String())
+	}
+}
+
+func TestDecomposeMeridiem(t *testing.T) {
+	t.Parallel()
+
+	fields, err := cron.Decompose("0 9AM-5PM * * *")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if fields.Hour.Kind != cron.FieldRange || fields.Hour.Start != 9 || fields.Hour.End != 17 {
+		t.Errorf("Unexpected field for 12-hour range: %+v", fields.Hour)
+	}
+}
@@ -0,0 +1,71 @@
+package cron
+
+import (
+	"time"
+)
+
+// MatchResult describes which components of a job's pattern matched a given time
+type MatchResult struct {
+	// The pattern that was evaluated. If the job has multiple patterns, this is the first pattern that matched, or the
+	// first pattern if none matched.
+	Pattern string
+	// If the seconds component matched. Always true for jobs that don't use Seconds.
+	Second bool
+	// If the minute component matched
+	Minute bool
+	// If the hour component matched
+	Hour bool
+	// If the day of month component matched
+	DayOfMonth bool
+	// If the month component matched
+	Month bool
+	// If the day of week component matched
+	DayOfWeek bool
+	// If the day of month and day of week were OR-d, because neither was a wildcard. Otherwise, both must match.
+	DayOfMonthOrDayOfWeek bool
+	// If the date matched, considering both the day of month and day of week
+	Date bool
+	// If the job would run at the time
+	Matches bool
+}
+
+// MatchDetail returns a breakdown of which components of this job's pattern match the given time, which is useful for
+// understanding why a job does or doesn't run. The time is evaluated as-is, without converting it to any timezone. An
+// empty result is returned for invalid patterns or jobs that run at a fixed interval with Every.
+func (job Job) MatchDetail(t time.Time) MatchResult {
+	if job.every() > 0 || job.Validate() != nil {
+		return MatchResult{}
+	}
+
+	patterns := job.patternStrings()
+	var first MatchResult
+	for i, pattern := range job.parse() {
+		result := pattern.matchDetail(t)
+		result.Pattern = patterns[i]
+		if result.Matches {
+			return result
+		}
+		if i == 0 {
+			first = result
+		}
+	}
+	return first
+}
+
+// matchDetail returns a breakdown of which components of the pattern match the given time
+func (p parsedPattern) matchDetail(clock time.Time) MatchResult {
+	result := MatchResult{
+		Second: p.seconds == "" || isItTime(p.seconds, clock.Second()),
+		Minute: isItTime(p.components[0], clock.Minute()),
+		Hour:   isItTime(p.components[1], clock.Hour()),
+		Month:  isItTime(p.components[3], int(clock.Month())),
+	}
+	result.DayOfMonth, result.DayOfWeek, result.DayOfMonthOrDayOfWeek = dateMatchDetail(p.components, clock)
+	if result.DayOfMonthOrDayOfWeek {
+		result.Date = result.DayOfMonth || result.DayOfWeek
+	} else {
+		result.Date = result.DayOfMonth && result.DayOfWeek
+	}
+	result.Matches = result.Second && result.Minute && result.Hour && result.Month && result.Date
+	return result
+}
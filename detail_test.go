@@ -0,0 +1,51 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestMatchDetail(t *testing.T) {
+	t.Parallel()
+
+	// January 4th 2021 is a Monday
+	clock := time.Date(2021, time.January, 4, 9, 30, 0, 0, time.UTC)
+
+	result := cron.Job{Pattern: "0 9 13 * FRI"}.MatchDetail(clock)
+	expected := cron.MatchResult{
+		Pattern:               "0 9 13 * FRI",
+		Second:                true,
+		Minute:                false,
+		Hour:                  true,
+		DayOfMonth:            false,
+		Month:                 true,
+		DayOfWeek:             false,
+		DayOfMonthOrDayOfWeek: true,
+		Date:                  false,
+		Matches:               false,
+	}
+	if result != expected {
+		t.Errorf("Unexpected match detail.\nExpected %+v\nGot      %+v", expected, result)
+	}
+
+	result = cron.Job{Pattern: "30 9 * * FRI"}.MatchDetail(clock)
+	if !result.Minute || !result.Hour || !result.DayOfMonth || result.DayOfWeek || result.DayOfMonthOrDayOfWeek || result.Date || result.Matches {
+		t.Errorf("Unexpected match detail for wildcard day of month %+v", result)
+	}
+
+	result = cron.Job{Pattern: "0 0 1 1 *", Patterns: []string{"30 9 * * MON"}}.MatchDetail(clock)
+	if !result.Matches || result.Pattern != "30 9 * * MON" {
+		t.Errorf("Unexpected match detail for multiple patterns %+v", result)
+	}
+
+	result = cron.Job{Pattern: "15 30 9 * * *", Seconds: true}.MatchDetail(clock)
+	if result.Second || !result.Minute || result.Matches {
+		t.Errorf("Unexpected match detail for seconds %+v", result)
+	}
+
+	if result := (cron.Job{Pattern: "0 25 * * *"}).MatchDetail(clock); result != (cron.MatchResult{}) {
+		t.Errorf("Unexpected match detail for invalid pattern %+v", result)
+	}
+}
@@ -0,0 +1,54 @@
+package cron
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// NewFromEnv will create a new tab from environment variables starting with the given prefix, such as
+// CRON_BACKUP=0 2 * * * for the prefix CRON_. The remainder of the variable name is the name of the job, which is
+// matched to a method in execs by name, ignoring case. An error is returned if any variable has an invalid pattern or
+// does not have a method. Methods without a variable are not scheduled. Jobs are sorted by name.
+func NewFromEnv(prefix string, execs map[string]func()) (*Tab, error) {
+	jobs := []Job{}
+	for _, env := range os.Environ() {
+		key, pattern, _ := strings.Cut(env, "=")
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		name, exec := findExec(strings.TrimPrefix(key, prefix), execs)
+		if exec == nil {
+			return nil, fmt.Errorf("environment variable %s: no method for job", key)
+		}
+		job := Job{
+			Pattern: strings.TrimSpace(pattern),
+			Name:    name,
+			Exec:    exec,
+		}
+		if err := job.Validate(); err != nil {
+			return nil, fmt.Errorf("environment variable %s: %w", key, err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Name < jobs[j].Name
+	})
+	return New(jobs)
+}
+
+// findExec returns the name and method from execs matching the given name, ignoring case
+func findExec(name string, execs map[string]func()) (string, func()) {
+	if exec, ok := execs[name]; ok {
+		return name, exec
+	}
+	for key, exec := range execs {
+		if strings.EqualFold(key, name) {
+			return key, exec
+		}
+	}
+	return "", nil
+}
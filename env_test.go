@@ -0,0 +1,59 @@
+package cron_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestNewFromEnv(t *testing.T) {
+	t.Parallel()
+
+	os.Setenv("CRONTEST_ENV_BACKUP", "0 2 * * *")
+	os.Setenv("CRONTEST_ENV_CLEANUP", " */5 * * * * ")
+	defer os.Unsetenv("CRONTEST_ENV_BACKUP")
+	defer os.Unsetenv("CRONTEST_ENV_CLEANUP")
+
+	tab, err := cron.NewFromEnv("CRONTEST_ENV_", map[string]func(){
+		"backup":  func() {},
+		"CLEANUP": func() {},
+		"unused":  func() {},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if len(tab.Jobs) != 2 {
+		t.Fatalf("Unexpected number of jobs. Expected %d got %d", 2, len(tab.Jobs))
+	}
+	if tab.Jobs[0].Name != "CLEANUP" || tab.Jobs[0].Pattern != "*/5 * * * *" {
+		t.Errorf("Unexpected first job %+v", tab.Jobs[0])
+	}
+	if tab.Jobs[1].Name != "backup" || tab.Jobs[1].Pattern != "0 2 * * *" || tab.Jobs[1].Exec == nil {
+		t.Errorf("Unexpected second job %+v", tab.Jobs[1])
+	}
+}
+
+func TestNewFromEnvInvalid(t *testing.T) {
+	t.Parallel()
+
+	os.Setenv("CRONTEST_INVALID_BACKUP", "0 25 * * *")
+	defer os.Unsetenv("CRONTEST_INVALID_BACKUP")
+
+	_, err := cron.NewFromEnv("CRONTEST_INVALID_", map[string]func(){
+		"backup": func() {},
+	})
+	if err == nil {
+		t.Fatalf("No error seen for invalid pattern")
+	}
+	if !strings.Contains(err.Error(), "CRONTEST_INVALID_BACKUP") {
+		t.Errorf("Error does not name the variable: %s", err.Error())
+	}
+
+	os.Setenv("CRONTEST_MISSING_BACKUP", "0 2 * * *")
+	defer os.Unsetenv("CRONTEST_MISSING_BACKUP")
+	if _, err := cron.NewFromEnv("CRONTEST_MISSING_", map[string]func(){}); err == nil || !strings.Contains(err.Error(), "CRONTEST_MISSING_BACKUP") {
+		t.Errorf("Unexpected error for variable without a method: %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors returned when validating patterns and jobs. The returned errors describe the problem and the component it was
+// found in, and can be matched against these errors with errors.Is.
+var (
+	// ErrTooFewFields is returned for a pattern with fewer components than expected
+	ErrTooFewFields = errors.New("too few fields")
+	// ErrTooManyFields is returned for a pattern with more components than expected
+	ErrTooManyFields = errors.New("too many fields")
+	// ErrInvalidValue is returned for a single value that is not a number or is out of bounds for the component
+	ErrInvalidValue = errors.New("invalid value")
+	// ErrInvalidRange is returned for a malformed range or a range that is out of bounds for the component
+	ErrInvalidRange = errors.New("invalid range")
+	// ErrInvalidStep is returned for a malformed step or a step that is out of bounds for the component
+	ErrInvalidStep = errors.New("invalid step")
+	// ErrInvalidList is returned for a list with an empty element or an element that is out of bounds for the component
+	ErrInvalidList = errors.New("invalid list")
+	// ErrInvalidName is returned for a named value that doesn't exist or is used in a component that doesn't allow names
+	ErrInvalidName = errors.New("invalid name")
+	// ErrInvalidEvery is returned for a job with an invalid fixed interval
+	ErrInvalidEvery = errors.New("invalid every")
+	// ErrInvalidWindow is returned for a job with a window that doesn't start and end at a time of day
+	ErrInvalidWindow = errors.New("invalid window")
+	// ErrDuplicateName is returned when more than one job in a tab shares the same name
+	ErrDuplicateName = errors.New("duplicate job name")
+	// ErrNeverRuns is returned by strict validation for a pattern that can never run
+	ErrNeverRuns = errors.New("pattern never runs")
+)
+
+// validationError is an error with a descriptive message that matches one of the exported errors with errors.Is
+type validationError struct {
+	kind    error
+	message string
+}
+
+func (e *validationError) Error() string {
+	return e.message
+}
+
+func (e *validationError) Unwrap() error {
+	return e.kind
+}
+
+// validationErrorf returns an error with the formatted message that matches kind with errors.Is
+func validationErrorf(kind error, format string, a ...interface{}) error {
+	return &validationError{
+		kind:    kind,
+		message: fmt.Sprintf(format, a...),
+	}
+}
+
+// tabValidationError describes every problem found when validating a tab, and matches each of them with errors.Is
+type tabValidationError struct {
+	problems []error
+}
+
+func (e *tabValidationError) Error() string {
+	messages := make([]string, len(e.problems))
+	for i, problem := range e.problems {
+		messages[i] = problem.Error()
+	}
+	return "invalid tab: " + strings.Join(messages, "; ")
+}
+
+func (e *tabValidationError) Is(target error) bool {
+	for _, problem := range e.problems {
+		if errors.Is(problem, target) {
+			return true
+		}
+	}
+	return false
+}
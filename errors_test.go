@@ -0,0 +1,86 @@
+package cron_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestValidationErrors(t *testing.T) {
+	t.Parallel()
+
+	expect := func(job cron.Job, expected error) {
+		err := job.Validate()
+		if err == nil {
+			t.Errorf("No error seen for pattern '%s'", job.Pattern)
+			return
+		}
+		if !errors.Is(err, expected) {
+			t.Errorf("Error for pattern '%s' is not %v: %s", job.Pattern, expected, err.Error())
+		}
+	}
+
+	expect(cron.Job{Pattern: "* * * *"}, cron.ErrTooFewFields)
+	expect(cron.Job{Pattern: "* * * * * *"}, cron.ErrTooManyFields)
+	expect(cron.Job{Pattern: "*", Seconds: true}, cron.ErrTooFewFields)
+	expect(cron.Job{Pattern: "60 * * * *"}, cron.ErrInvalidValue)
+	expect(cron.Job{Pattern: "a * * * *"}, cron.ErrInvalidName)
+	expect(cron.Job{Pattern: "0 0 * * Fryday"}, cron.ErrInvalidName)
+	expect(cron.Job{Pattern: "5-1 * * * *"}, cron.ErrInvalidRange)
+	expect(cron.Job{Pattern: "0-60 * * * *"}, cron.ErrInvalidRange)
+	expect(cron.Job{Pattern: "*/0 * * * *"}, cron.ErrInvalidStep)
+	expect(cron.Job{Pattern: "*/60 * * * *"}, cron.ErrInvalidStep)
+	expect(cron.Job{Pattern: "1,,2 * * * *"}, cron.ErrInvalidList)
+	expect(cron.Job{Pattern: "1,60 * * * *"}, cron.ErrInvalidList)
+	expect(cron.Job{Pattern: "@every forever"}, cron.ErrInvalidEvery)
+	expect(cron.Job{Every: -time.Minute}, cron.ErrInvalidEvery)
+
+	if err := (cron.Job{Pattern: "0 0 30 2 *"}).ValidateStrict(); !errors.Is(err, cron.ErrNeverRuns) {
+		t.Errorf("Unexpected error for pattern that never runs: %v", err)
+	}
+	if _, err := cron.New([]cron.Job{{Name: "A", Pattern: "* * * * *"}, {Name: "A", Pattern: "* * * * *"}}); !errors.Is(err, cron.ErrDuplicateName) {
+		t.Errorf("Unexpected error for duplicate job names: %v", err)
+	}
+
+	// The descriptive message is not changed
+	err := cron.Job{Pattern: "0-60 * * * *"}.Validate()
+	if err.Error() != "invalid minute range: minute must be 0-59" {
+		t.Errorf("Unexpected error message '%s'", err.Error())
+	}
+
+	// Errors are still matched when wrapped with the line of a crontab
+	_, err = cron.Parse(strings.NewReader("60 * * * * backup\n"), func(command string, env map[string]string) func() { return func() {} })
+	if !errors.Is(err, cron.ErrInvalidValue) {
+		t.Errorf("Unexpected error parsing crontab: %v", err)
+	}
+}
+
+func TestTabValidationErrors(t *testing.T) {
+	t.Parallel()
+
+	tab := &cron.Tab{
+		Jobs: []cron.Job{
+			{Name: "Same", Pattern: "* * * * *"},
+			{Name: "Same", Pattern: "* * * *"},
+			{Pattern: "0 0 * * MONDAYS"},
+		},
+	}
+	err := tab.Validate()
+	if err == nil {
+		t.Fatalf("No error seen for invalid tab")
+	}
+	for _, expected := range []error{cron.ErrDuplicateName, cron.ErrTooFewFields, cron.ErrInvalidName} {
+		if !errors.Is(err, expected) {
+			t.Errorf("Tab validation error '%s' does not match '%s'", err.Error(), expected.Error())
+		}
+	}
+	if errors.Is(err, cron.ErrInvalidStep) {
+		t.Errorf("Tab validation error '%s' unexpectedly matches '%s'", err.Error(), cron.ErrInvalidStep.Error())
+	}
+	if !strings.HasPrefix(err.Error(), "invalid tab: duplicate job name 'Same'; job 'Same': ") {
+		t.Errorf("Unexpected tab validation error '%s'", err.Error())
+	}
+}
@@ -0,0 +1,95 @@
+package cron
+
+import (
+	"time"
+)
+
+// EventType describes the type of a job lifecycle event
+type EventType string
+
+const (
+	// EventStart is emitted right before a job is executed
+	EventStart EventType = "start"
+	// EventFinish is emitted after a job has finished executing without panicking or returning an error
+	EventFinish EventType = "finish"
+	// EventPanic is emitted after a job panicked
+	EventPanic EventType = "panic"
+	// EventError is emitted after a job using ExecE returned an error
+	EventError EventType = "error"
+	// EventSkip is emitted when a job was due but was not executed, such as when the tab is in DryRun mode
+	EventSkip EventType = "skip"
+)
+
+// SkipReason describes why a job that was due was not executed
+type SkipReason string
+
+const (
+	// SkipDryRun is when the tab is in DryRun mode
+	SkipDryRun SkipReason = "dry_run"
+	// SkipHoliday is when the job's Holiday method returned true
+	SkipHoliday SkipReason = "holiday"
+	// SkipRunLimit is when the job has already run as many times as its RunLimit
+	SkipRunLimit SkipReason = "run_limit"
+	// SkipQueueFull is when the job was dropped from the full queue of a tab using Workers and QueueDropOldest
+	SkipQueueFull SkipReason = "queue_full"
+	// SkipStopped is when the tab stopped while the job was waiting for its Jitter
+	SkipStopped SkipReason = "stopped"
+	// SkipPaused is when the job's group is paused with PauseGroup
+	SkipPaused SkipReason = "paused"
+	// SkipFailed is when the job uses SkipAfterError and a previous run failed
+	SkipFailed SkipReason = "failed"
+)
+
+// Event describes something that happened to a job during its lifecycle
+type Event struct {
+	// The type of event
+	Type EventType `json:"type"`
+	// The name of the job
+	JobName string `json:"job_name"`
+	// The tags of the job
+	JobTags map[string]string `json:"job_tags,omitempty"`
+	// When the event happened
+	Timestamp time.Time `json:"timestamp"`
+	// How long the job ran for. Only populated for finish, panic, and error events.
+	Elapsed time.Duration `json:"elapsed,omitempty"`
+	// The value the job panicked with or the error it returned. Only populated for panic and error events.
+	Error string `json:"error,omitempty"`
+	// Why the job was not executed. Only populated for skip events.
+	Reason SkipReason `json:"reason,omitempty"`
+}
+
+// skip records that the job was due but was not executed for the given reason
+func (s *Tab) skip(job Job, reason SkipReason) {
+	log.PDebug("Skipped job", map[string]interface{}{
+		"name":   job.Name,
+		"reason": string(reason),
+	})
+	if s.EventSink != nil {
+		event := s.newEvent(EventSkip, job, 0, "")
+		event.Reason = reason
+		s.EventSink(event)
+	}
+	if s.OnSkip != nil {
+		s.OnSkip(job, reason)
+	}
+}
+
+// emit sends the event to the tabs event sink, if one is set
+func (s *Tab) emit(eventType EventType, job Job, elapsed time.Duration, err string) {
+	if s.EventSink == nil {
+		return
+	}
+	s.EventSink(s.newEvent(eventType, job, elapsed, err))
+}
+
+// newEvent returns an event of the given type for the job that happened now
+func (s *Tab) newEvent(eventType EventType, job Job, elapsed time.Duration, err string) Event {
+	return Event{
+		Type:      eventType,
+		JobName:   job.Name,
+		JobTags:   job.Tags,
+		Timestamp: s.getClock().Now(),
+		Elapsed:   elapsed,
+		Error:     err,
+	}
+}
@@ -0,0 +1,146 @@
+package cron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventSink(t *testing.T) {
+	t.Parallel()
+
+	lock := sync.Mutex{}
+	events := map[string][]Event{}
+
+	tab, _ := New([]Job{
+		{
+			Name:    "Normal",
+			Pattern: "* * * * *",
+			Exec:    func() {},
+		},
+		{
+			Name:    "Panics",
+			Pattern: "* * * * *",
+			Exec: func() {
+				panic("(intentional panic)")
+			},
+		},
+	})
+	tab.TZ = time.UTC
+	tab.EventSink = func(event Event) {
+		lock.Lock()
+		events[event.JobName] = append(events[event.JobName], event)
+		lock.Unlock()
+	}
+
+	start := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	_, stop := startFakeTab(tab, start)
+	stop()
+
+	expect := func(name string, types ...EventType) {
+		if len(events[name]) != len(types) {
+			t.Errorf("Unexpected number of events for job %s. Expected %d got %d", name, len(types), len(events[name]))
+			return
+		}
+		for i, event := range events[name] {
+			if event.Type != types[i] {
+				t.Errorf("Unexpected event %d for job %s. Expected %s got %s", i, name, types[i], event.Type)
+			}
+			if !event.Timestamp.Equal(start) {
+				t.Errorf("Unexpected event timestamp %s", event.Timestamp)
+			}
+		}
+	}
+
+	expect("Normal", EventStart, EventFinish)
+	expect("Panics", EventStart, EventPanic)
+	if len(events["Panics"]) == 2 && events["Panics"][1].Error != "(intentional panic)" {
+		t.Errorf("Unexpected panic event error '%s'", events["Panics"][1].Error)
+	}
+}
+
+func TestJobTags(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := New([]Job{
+		{
+			Name:    "Tagged",
+			Pattern: "* * * * *",
+			Tags:    map[string]string{"team": "platform"},
+			Exec:    func() {},
+		},
+	})
+	if tab.Jobs[0].Tags["team"] != "platform" {
+		t.Fatalf("Tags not preserved through New")
+	}
+	tab.TZ = time.UTC
+
+	lock := sync.Mutex{}
+	startTags := map[string]string{}
+	eventTags := map[string]string{}
+	tab.OnJobStart = func(job Job, dryRun bool) {
+		lock.Lock()
+		startTags = job.Tags
+		lock.Unlock()
+	}
+	tab.EventSink = func(event Event) {
+		lock.Lock()
+		eventTags = event.JobTags
+		lock.Unlock()
+	}
+
+	start := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	_, stop := startFakeTab(tab, start)
+	stop()
+
+	if startTags["team"] != "platform" {
+		t.Errorf("Tags not passed to OnJobStart")
+	}
+	if eventTags["team"] != "platform" {
+		t.Errorf("Tags not included in events")
+	}
+}
+
+func TestEventSinkSkipReason(t *testing.T) {
+	t.Parallel()
+
+	var lock sync.Mutex
+	reasons := map[string]SkipReason{}
+	start := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	tab, _ := New([]Job{
+		{
+			Name:    "Holiday",
+			Pattern: "* * * * *",
+			Holiday: Holidays(start),
+			Exec:    func() {},
+		},
+		{
+			Name:    "Paused",
+			Group:   "paused",
+			Pattern: "* * * * *",
+			Exec:    func() {},
+		},
+	})
+	tab.TZ = time.UTC
+	tab.PauseGroup("paused")
+	tab.EventSink = func(event Event) {
+		if event.Type != EventSkip {
+			return
+		}
+		lock.Lock()
+		reasons[event.JobName] = event.Reason
+		lock.Unlock()
+	}
+
+	_, stop := startFakeTab(tab, start)
+	stop()
+
+	lock.Lock()
+	defer lock.Unlock()
+	if reasons["Holiday"] != SkipHoliday {
+		t.Errorf("Unexpected skip reason for holiday. Expected %s got %s", SkipHoliday, reasons["Holiday"])
+	}
+	if reasons["Paused"] != SkipPaused {
+		t.Errorf("Unexpected skip reason for paused group. Expected %s got %s", SkipPaused, reasons["Paused"])
+	}
+}
@@ -0,0 +1,41 @@
+package cron
+
+// ClearError clears the failure of the named job using SkipAfterError, so that it runs again the next time it is due.
+// Does nothing if the job has not failed.
+func (s *Tab) ClearError(name string) {
+	s.setJobFailed(name, false)
+}
+
+// Failed returns true if the named job uses SkipAfterError and is being skipped because a previous run failed
+func (s *Tab) Failed(name string) bool {
+	return s.jobFailed(name)
+}
+
+// skippedForError returns true if the job uses SkipAfterError and has failed, so it is skipped until ClearError
+func (s *Tab) skippedForError(job Job) bool {
+	return job.SkipAfterError && s.jobFailed(job.Name)
+}
+
+// setJobFailed records whether the named job has failed
+func (s *Tab) setJobFailed(name string, failed bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !failed {
+		delete(s.failedJobs, name)
+		return
+	}
+	if s.failedJobs == nil {
+		s.failedJobs = map[string]bool{}
+	}
+	s.failedJobs[name] = true
+	log.PWarn("Job failed and will be skipped until its error is cleared", map[string]interface{}{
+		"name": name,
+	})
+}
+
+// jobFailed returns true if the named job has failed
+func (s *Tab) jobFailed(name string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.failedJobs[name]
+}
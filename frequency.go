@@ -0,0 +1,51 @@
+package cron
+
+import (
+	"time"
+)
+
+// Frequency returns the average number of times per day that the pattern runs, such as 288 for */5 * * * *. The
+// average is taken over the full 400 year cycle of the Gregorian calendar, so patterns that only run on some days, such
+// as 0 0 1 * * or 0 0 * * MON, return a fraction. Fixed interval patterns, such as @every 1h, return the number of
+// intervals in a day. An error is returned if the pattern is not valid.
+func Frequency(pattern string) (runsPerDay float64, err error) {
+	job := Job{Pattern: pattern}
+	if err := job.Validate(); err != nil {
+		return 0, err
+	}
+	if every := job.every(); every > 0 {
+		return float64(24*time.Hour) / float64(every), nil
+	}
+	components := job.parse()[0].components
+
+	minutes := 0
+	for minute := 0; minute < 60; minute++ {
+		if isItTime(components[0], minute) {
+			minutes++
+		}
+	}
+	hours := 0
+	for hour := 0; hour < 24; hour++ {
+		if isItTime(components[1], hour) {
+			hours++
+		}
+	}
+	if minutes == 0 || hours == 0 {
+		return 0, nil
+	}
+
+	// Rather than trying to estimate the interaction between the day of month, month, and day of week, count how many
+	// days actually match over a cycle that repeats exactly
+	start := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(400, 0, 0)
+	days := 0
+	matchingDays := 0
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		days++
+		if isItTime(components[3], int(day.Month())) && dateDoesMatch(components, day) {
+			matchingDays++
+		}
+	}
+
+	return float64(minutes*hours) * float64(matchingDays) / float64(days), nil
+}
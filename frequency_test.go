@@ -0,0 +1,39 @@
+package cron_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestFrequency(t *testing.T) {
+	t.Parallel()
+
+	expect := func(pattern string, expected float64) {
+		runsPerDay, err := cron.Frequency(pattern)
+		if err != nil {
+			t.Errorf("Unexpected error for pattern '%s': %s", pattern, err.Error())
+			return
+		}
+		if math.Abs(runsPerDay-expected) > 0.001 {
+			t.Errorf("Unexpected frequency for pattern '%s'. Expected %f got %f", pattern, expected, runsPerDay)
+		}
+	}
+
+	expect("* * * * *", 1440)
+	expect("*/5 * * * *", 288)
+	expect("0 * * * *", 24)
+	expect("0 9-17 * * *", 9)
+	expect("0 0 * * *", 1)
+	expect("0 0 * * MON", 1.0/7)
+	expect("0 9 * * MON-FRI", 5.0/7)
+	expect("0 0 1 1 *", 1/365.2425)
+	expect("0 0 29 2 *", 97/146097.0)
+	expect("0 0 31 4 *", 0)
+	expect("@every 90m", 16)
+
+	if _, err := cron.Frequency("0 25 * * *"); err == nil {
+		t.Errorf("No error seen for invalid pattern")
+	}
+}
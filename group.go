@@ -0,0 +1,88 @@
+package cron
+
+import (
+	"time"
+)
+
+// GroupStats describes a snapshot of the state of every job in a group
+type GroupStats struct {
+	// If the group is paused with PauseGroup
+	Paused bool `json:"paused"`
+	// The number of jobs in the group
+	Jobs int `json:"jobs"`
+	// The number of times jobs in the group have been executed by this tab
+	Runs uint64 `json:"runs"`
+	// The number of times jobs in the group have panicked while being executed by this tab
+	Panics uint64 `json:"panics"`
+	// The number of jobs in the group that are currently executing
+	Running int `json:"running"`
+	// The last time any job in the group ran. Nil if no job in the group has run.
+	LastRun *time.Time `json:"last_run,omitempty"`
+}
+
+// PauseGroup pauses every job with the given group, so that they are skipped with SkipPaused when they are due. Jobs
+// that are currently executing are not interrupted. Pausing a group that is already paused does nothing.
+func (s *Tab) PauseGroup(group string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.pausedGroups == nil {
+		s.pausedGroups = map[string]bool{}
+	}
+	s.pausedGroups[group] = true
+	log.PDebug("Paused group", map[string]interface{}{
+		"group": group,
+	})
+}
+
+// ResumeGroup resumes every job with the given group that was paused with PauseGroup, so that they run the next time
+// they are due. Runs that were skipped while paused are not caught up.
+func (s *Tab) ResumeGroup(group string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.pausedGroups, group)
+	log.PDebug("Resumed group", map[string]interface{}{
+		"group": group,
+	})
+}
+
+// GroupPaused returns true if the given group is paused
+func (s *Tab) GroupPaused(group string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.pausedGroups[group]
+}
+
+// GroupStats returns a snapshot of the state of every job with the given group. Statistics are recorded by job name, so
+// jobs in the group without a name are counted but do not contribute to the runs, panics, or last run.
+func (s *Tab) GroupStats(group string) GroupStats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stats := GroupStats{
+		Paused: s.pausedGroups[group],
+	}
+	for _, job := range s.Jobs {
+		if job.Group != group {
+			continue
+		}
+		stats.Jobs++
+		if job.Name == "" {
+			continue
+		}
+		stats.Runs += s.jobRuns[job.Name]
+		stats.Panics += s.jobPanics[job.Name]
+		stats.Running += s.jobsRunning[job.Name]
+		if lastRun, ok := s.lastRuns[job.Name]; ok && (stats.LastRun == nil || lastRun.After(*stats.LastRun)) {
+			stats.LastRun = &lastRun
+		}
+	}
+	return stats
+}
+
+// groupPaused returns true if the job belongs to a group that is paused
+func (s *Tab) groupPaused(job Job) bool {
+	if job.Group == "" {
+		return false
+	}
+	return s.GroupPaused(job.Group)
+}
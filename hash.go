@@ -0,0 +1,71 @@
+package cron
+
+import (
+	"hash/fnv"
+	"regexp"
+	"strconv"
+)
+
+var hashedPattern = regexp.MustCompile(`(?i)^H(\(([0-9]+)-([0-9]+)\))?$`)
+
+// validateHashed validates a hashed component, such as H or H(0-29)
+func validateHashed(component string, unit string, i int) error {
+	low, high, err := hashedRange(component, i)
+	if err != nil {
+		return validationErrorf(ErrInvalidValue, "invalid %s hash: %s", unit, err.Error())
+	}
+	if low >= high {
+		return validationErrorf(ErrInvalidValue, "invalid %s hash", unit)
+	}
+	if !validateDateComponent(low, i) || !validateDateComponent(high, i) {
+		return boundsError(unit, "hash", i)
+	}
+	return nil
+}
+
+// hashedRange returns the range of values that a hashed component can resolve to
+func hashedRange(component string, i int) (low int, high int, err error) {
+	match := hashedPattern.FindStringSubmatch(component)
+	if match[1] == "" {
+		low, high = hashBounds(i)
+		return
+	}
+	if low, err = strconv.Atoi(match[2]); err != nil {
+		return
+	}
+	high, err = strconv.Atoi(match[3])
+	return
+}
+
+// hashBounds returns the default range of values for a hashed or random component. The day of month is limited to 1-28
+// so that the job runs every month.
+func hashBounds(i int) (low int, high int) {
+	switch i {
+	case 0, secondComponent:
+		return 0, 59
+	case 1:
+		return 0, 23
+	case 2:
+		return 1, 28
+	case 3:
+		return 1, 12
+	case 4:
+		return 0, 6
+	}
+	return 0, 0
+}
+
+// resolveHashed returns the value that a hashed component resolves to for the job with the given name. The value is
+// the same every time for the same name and component, but differs between names so that jobs are spread out. Returns
+// the component as-is if it isn't hashed. This assumes the component has already been validated.
+func resolveHashed(component string, name string, i int) string {
+	if !hashedPattern.MatchString(component) {
+		return component
+	}
+
+	low, high, _ := hashedRange(component, i)
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	h.Write([]byte{byte(i)})
+	return strconv.Itoa(low + int(h.Sum32()%uint32(high-low+1)))
+}
@@ -0,0 +1,72 @@
+package cron_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestHashedPattern(t *testing.T) {
+	t.Parallel()
+
+	minute := func(name string, pattern string) int {
+		m, _, _, _, _ := cron.Job{Name: name, Pattern: pattern}.Fields()
+		v, err := strconv.Atoi(m)
+		if err != nil {
+			t.Fatalf("Hashed minute for job '%s' did not resolve to a value: '%s'", name, m)
+		}
+		return v
+	}
+
+	backup := minute("backup", "H * * * *")
+	cleanup := minute("cleanup", "H * * * *")
+	if backup == cleanup {
+		t.Errorf("Jobs with different names resolved to the same minute %d", backup)
+	}
+	if backup != 25 || cleanup != 57 {
+		t.Errorf("Unexpected hashed minutes %d and %d", backup, cleanup)
+	}
+	if again := minute("backup", "H * * * *"); again != backup {
+		t.Errorf("Hashed minute is not stable. Expected %d got %d", backup, again)
+	}
+
+	for _, name := range []string{"backup", "cleanup", "report", "sync"} {
+		if v := minute(name, "H(0-29) * * * *"); v < 0 || v > 29 {
+			t.Errorf("Hashed minute %d for job '%s' outside of range", v, name)
+		}
+	}
+
+	job := cron.Job{Name: "backup", Pattern: "H * * * *"}
+	next, _ := job.NextRun(time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC))
+	if !next.Equal(time.Date(2021, time.January, 1, 0, 25, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected next run %s", next)
+	}
+
+	job = cron.Job{Name: "backup", Pattern: "H H H * *"}
+	if _, _, dayOfMonth, _, _ := job.Fields(); dayOfMonth == "H" {
+		t.Errorf("Hashed day of month was not resolved")
+	}
+}
+
+func TestValidateHashedPattern(t *testing.T) {
+	t.Parallel()
+
+	expect := func(e bool, p string) {
+		r := cron.Job{Pattern: p}.Validate()
+		if (r == nil) != e {
+			t.Errorf("Incorrect validation result for pattern '%s'. Error: %v", p, r)
+		}
+	}
+
+	expect(true, "H * * * *")
+	expect(true, "h H H H H")
+	expect(true, "H(0-29) * * * *")
+	expect(true, "0 H(9-17) * * *")
+	expect(false, "H(0-60) * * * *")
+	expect(false, "H(30-10) * * * *")
+	expect(false, "H(10) * * * *")
+	expect(false, "H,5 * * * *")
+	expect(false, "HH * * * *")
+}
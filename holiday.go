@@ -0,0 +1,22 @@
+package cron
+
+import (
+	"time"
+)
+
+// Holidays returns a method for the Holiday field of a job that returns true for any time on one of the given dates.
+// Only the year, month, and day of each date are used, in the location of the date.
+func Holidays(dates ...time.Time) func(t time.Time) bool {
+	type day struct {
+		year  int
+		month time.Month
+		day   int
+	}
+	days := map[day]bool{}
+	for _, date := range dates {
+		days[day{date.Year(), date.Month(), date.Day()}] = true
+	}
+	return func(t time.Time) bool {
+		return days[day{t.Year(), t.Month(), t.Day()}]
+	}
+}
@@ -0,0 +1,32 @@
+package cron
+
+import (
+	"time"
+)
+
+// AutoInterval sets the Interval of the tab to the longest interval that still checks for jobs often enough for every
+// job to run on time. Tabs with only minute based patterns check every minute, tabs with any job using Seconds check
+// every second, and jobs using Every are checked at an interval that evenly divides their duration.
+func (s *Tab) AutoInterval() {
+	interval := time.Minute
+	for _, job := range s.Jobs {
+		if job.every() > 0 {
+			every := job.every().Truncate(time.Second)
+			if every < time.Second {
+				every = time.Second
+			}
+			interval = gcdDuration(interval, every)
+		} else if job.Seconds {
+			interval = time.Second
+		}
+	}
+	s.Interval = interval
+}
+
+// gcdDuration returns the greatest common divisor of two durations
+func gcdDuration(a, b time.Duration) time.Duration {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
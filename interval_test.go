@@ -0,0 +1,32 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestAutoInterval(t *testing.T) {
+	t.Parallel()
+
+	expect := func(expected time.Duration, jobs ...cron.Job) {
+		tab, err := cron.New(jobs)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+		tab.Interval = 5 * time.Minute
+		tab.AutoInterval()
+		if tab.Interval != expected {
+			t.Errorf("Unexpected interval. Expected %s got %s", expected, tab.Interval)
+		}
+	}
+
+	expect(time.Minute, cron.Job{Pattern: "* * * * *"}, cron.Job{Pattern: "0 0 * * *"})
+	expect(time.Second, cron.Job{Pattern: "0 0 * * *"}, cron.Job{Pattern: "*/15 * * * * *", Seconds: true})
+	expect(30*time.Second, cron.Job{Pattern: "0 0 * * *"}, cron.Job{Every: 90 * time.Second})
+	expect(time.Minute, cron.Job{Every: 5 * time.Minute})
+	expect(time.Second, cron.Job{Every: 1500 * time.Millisecond})
+	expect(time.Second, cron.Job{Every: 100 * time.Millisecond})
+	expect(time.Minute)
+}
@@ -0,0 +1,61 @@
+package cron
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/logtic"
+)
+
+// This test replaces the package logger, so it must not run in parallel with other tests
+func TestPanicLogFormat(t *testing.T) {
+	output := &bytes.Buffer{}
+	logger := logtic.New()
+	logger.Level = logtic.LevelDebug
+	logger.Stdout = output
+	logger.Stderr = output
+	if err := logger.Open(); err != nil {
+		t.Fatalf("Unexpected error opening logger: %s", err.Error())
+	}
+	defer logger.Close()
+
+	original := log
+	log = logger.Connect("cron")
+	defer func() {
+		log = original
+	}()
+
+	tab, _ := New([]Job{
+		{
+			Name:    "Panics",
+			Pattern: "* * * * *",
+			Exec: func() {
+				panic("oops")
+			},
+		},
+	})
+	tab.TZ = time.UTC
+	tab.Sequential = true
+	_, stop := startFakeTab(tab, time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC))
+	stop()
+
+	var errorLine, stackLine string
+	// Strip any colors from the output
+	plain := regexp.MustCompile("\x1b\\[[0-9;]*m").ReplaceAllString(output.String(), "")
+	for _, line := range strings.Split(plain, "\n") {
+		if strings.HasPrefix(line, "[ERROR][cron] Recovered from job panic") {
+			errorLine = line
+		} else if strings.HasPrefix(line, "[DEBUG][cron] Job panic stack trace:") {
+			stackLine = line
+		}
+	}
+	if errorLine != "[ERROR][cron] Recovered from job panic: error='oops' name='Panics'" {
+		t.Errorf("Unexpected error log line '%s'", errorLine)
+	}
+	if !strings.Contains(stackLine, "name='Panics' stack='goroutine") {
+		t.Errorf("Unexpected stack trace log line '%s'", stackLine)
+	}
+}
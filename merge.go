@@ -0,0 +1,50 @@
+package cron
+
+import (
+	"time"
+)
+
+// Merge will create a new tab with the jobs of each of the given tabs, in order. The new tab checks for jobs at the
+// smallest interval of any of the tabs, expires at the earliest expiry of any of the tabs, and uses the timezone of the
+// first tab. Nil tabs are ignored. Other options of the tabs are not copied, and the new tab is not started. An error
+// is returned if more than one job shares the same non-empty name.
+func Merge(tabs ...*Tab) (*Tab, error) {
+	jobs := []Job{}
+	var first *Tab
+	for _, tab := range tabs {
+		if tab == nil {
+			continue
+		}
+		if first == nil {
+			first = tab
+		}
+		jobs = append(jobs, tab.Clone().Jobs...)
+	}
+
+	merged, err := New(jobs)
+	if err != nil {
+		return nil, err
+	}
+	if first == nil {
+		return merged, nil
+	}
+
+	merged.TZ = first.TZ
+	merged.Interval = 0
+	for _, tab := range tabs {
+		if tab == nil {
+			continue
+		}
+		if tab.Interval > 0 && (merged.Interval == 0 || tab.Interval < merged.Interval) {
+			merged.Interval = tab.Interval
+		}
+		if tab.ExpireAfter != nil && (merged.ExpireAfter == nil || tab.ExpireAfter.Before(*merged.ExpireAfter)) {
+			expireAfter := *tab.ExpireAfter
+			merged.ExpireAfter = &expireAfter
+		}
+	}
+	if merged.Interval == 0 {
+		merged.Interval = 60 * time.Second
+	}
+	return merged, nil
+}
@@ -0,0 +1,61 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	billing, _ := cron.New([]cron.Job{
+		{Name: "Invoices", Pattern: "0 0 1 * *"},
+		{Name: "Reminders", Pattern: "0 9 * * *"},
+	})
+	billingExpiry := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	billing.ExpireAfter = &billingExpiry
+
+	reports, _ := cron.New([]cron.Job{
+		{Name: "Daily", Pattern: "0 6 * * *"},
+	})
+	reports.Interval = 30 * time.Second
+	reportsExpiry := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	reports.ExpireAfter = &reportsExpiry
+
+	merged, err := cron.Merge(billing, nil, reports)
+	if err != nil {
+		t.Fatalf("Unexpected error merging tabs: %s", err.Error())
+	}
+	if len(merged.Jobs) != 3 || merged.Jobs[0].Name != "Invoices" || merged.Jobs[2].Name != "Daily" {
+		t.Errorf("Unexpected jobs in merged tab: %v", merged.Jobs)
+	}
+	if merged.Interval != 30*time.Second {
+		t.Errorf("Unexpected interval. Expected %s got %s", 30*time.Second, merged.Interval)
+	}
+	if merged.ExpireAfter == nil || !merged.ExpireAfter.Equal(reportsExpiry) {
+		t.Errorf("Unexpected expiry. Expected %s got %v", reportsExpiry, merged.ExpireAfter)
+	}
+	if merged.Running() {
+		t.Errorf("Merged tab should not be running")
+	}
+
+	// Changing the merged tab should not change the original tabs
+	merged.Jobs[0].Pattern = "* * * * *"
+	*merged.ExpireAfter = time.Time{}
+	if billing.Jobs[0].Pattern != "0 0 1 * *" || !reports.ExpireAfter.Equal(reportsExpiry) {
+		t.Errorf("Original tabs were changed by changing the merged tab")
+	}
+
+	noExpiry, _ := cron.New([]cron.Job{{Name: "Other", Pattern: "0 0 * * *"}})
+	merged, _ = cron.Merge(noExpiry, billing)
+	if merged.ExpireAfter == nil || !merged.ExpireAfter.Equal(billingExpiry) || merged.Interval != 60*time.Second {
+		t.Errorf("Unexpected expiry or interval for merged tab")
+	}
+
+	duplicate, _ := cron.New([]cron.Job{{Name: "Daily", Pattern: "0 7 * * *"}})
+	if _, err := cron.Merge(reports, duplicate); err == nil {
+		t.Errorf("No error seen when merging tabs with duplicate job names")
+	}
+}
@@ -0,0 +1,28 @@
+package cron
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// meridiemPattern matches an hour in 12-hour form, such as 9AM or 5pm
+var meridiemPattern = regexp.MustCompile("(?i)\\b([0-9]+)(AM|PM)\\b")
+
+// replaceMeridiem replaces every hour in 12-hour form in the hour component, including those used in ranges and lists,
+// with its 24-hour value. 12AM is midnight and 12PM is noon. Hours outside of 1-12 are left as-is so that they fail
+// validation.
+func replaceMeridiem(component string) string {
+	return meridiemPattern.ReplaceAllStringFunc(component, func(element string) string {
+		match := meridiemPattern.FindStringSubmatch(element)
+		hour, err := strconv.Atoi(match[1])
+		if err != nil || hour < 1 || hour > 12 {
+			return element
+		}
+		hour = hour % 12
+		if strings.EqualFold(match[2], "PM") {
+			hour += 12
+		}
+		return strconv.Itoa(hour)
+	})
+}
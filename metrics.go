@@ -0,0 +1,78 @@
+package cron
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteMetrics will write metrics about this tab and its jobs to w in the Prometheus text exposition format, for
+// serving from a metrics endpoint. The following metrics are written:
+//
+//	cron_jobs                            The number of jobs in the tab
+//	cron_job_runs_total                  The number of times each job has been executed
+//	cron_job_panics_total                The number of times each job has panicked
+//	cron_job_last_run_timestamp_seconds  When each job was last executed, only for jobs that have run
+//
+// Each job metric has a job label with the name of the job. Jobs without a name are counted by cron_jobs but don't
+// have any job metrics.
+func (s *Tab) WriteMetrics(w io.Writer) error {
+	s.lock.Lock()
+	jobs := len(s.Jobs)
+	names := []string{}
+	runs := []uint64{}
+	panics := []uint64{}
+	lastRuns := []string{}
+	for _, job := range s.Jobs {
+		if job.Name == "" {
+			// Statistics are recorded by name, so unnamed jobs can't be told apart
+			continue
+		}
+		names = append(names, escapeLabel(job.Name))
+		runs = append(runs, s.jobRuns[job.Name])
+		panics = append(panics, s.jobPanics[job.Name])
+		lastRun := ""
+		if t, ok := s.lastRuns[job.Name]; ok {
+			lastRun = strconv.FormatFloat(float64(t.UnixMilli())/1000, 'f', -1, 64)
+		}
+		lastRuns = append(lastRuns, lastRun)
+	}
+	s.lock.Unlock()
+
+	lines := []string{
+		"# HELP cron_jobs The number of jobs in the tab.",
+		"# TYPE cron_jobs gauge",
+		fmt.Sprintf("cron_jobs %d", jobs),
+		"# HELP cron_job_runs_total The number of times the job has been executed.",
+		"# TYPE cron_job_runs_total counter",
+	}
+	for i, name := range names {
+		lines = append(lines, fmt.Sprintf("cron_job_runs_total{job=\"%s\"} %d", name, runs[i]))
+	}
+	lines = append(lines,
+		"# HELP cron_job_panics_total The number of times the job has panicked.",
+		"# TYPE cron_job_panics_total counter",
+	)
+	for i, name := range names {
+		lines = append(lines, fmt.Sprintf("cron_job_panics_total{job=\"%s\"} %d", name, panics[i]))
+	}
+	lines = append(lines,
+		"# HELP cron_job_last_run_timestamp_seconds When the job was last executed.",
+		"# TYPE cron_job_last_run_timestamp_seconds gauge",
+	)
+	for i, name := range names {
+		if lastRuns[i] == "" {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("cron_job_last_run_timestamp_seconds{job=\"%s\"} %s", name, lastRuns[i]))
+	}
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// escapeLabel escapes the value of a label for the Prometheus text format
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,87 @@
+package cron
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := New([]Job{
+		{
+			Name:    "Backup",
+			Pattern: "* * * * *",
+			Exec:    func() {},
+		},
+		{
+			Name:    "Flaky \"job\"",
+			Pattern: "* * * * *",
+			Exec: func() {
+				panic("oops")
+			},
+		},
+		{
+			Name:    "Never",
+			Pattern: "0 0 1 1 *",
+			Exec:    func() {},
+		},
+	})
+	tab.TZ = time.UTC
+	tab.Sequential = true
+
+	start := time.Date(2021, time.January, 1, 12, 0, 30, 0, time.UTC)
+	_, stop := startFakeTab(tab, start)
+	stop()
+
+	buf := &bytes.Buffer{}
+	if err := tab.WriteMetrics(buf); err != nil {
+		t.Fatalf("Unexpected error writing metrics: %s", err.Error())
+	}
+	metrics := buf.String()
+
+	expect := func(line string) {
+		if !strings.Contains(metrics, line+"\n") {
+			t.Errorf("Metrics did not contain line '%s':\n%s", line, metrics)
+		}
+	}
+	expect("# TYPE cron_jobs gauge")
+	expect("cron_jobs 3")
+	expect("# TYPE cron_job_runs_total counter")
+	expect(`cron_job_runs_total{job="Backup"} 1`)
+	expect(`cron_job_runs_total{job="Flaky \"job\""} 1`)
+	expect(`cron_job_runs_total{job="Never"} 0`)
+	expect(`cron_job_panics_total{job="Backup"} 0`)
+	expect(`cron_job_panics_total{job="Flaky \"job\""} 1`)
+	expect(`cron_job_last_run_timestamp_seconds{job="Backup"} 1609502430`)
+	if strings.Contains(metrics, `cron_job_last_run_timestamp_seconds{job="Never"}`) {
+		t.Errorf("Metrics contained last run for job that never ran:\n%s", metrics)
+	}
+}
+
+func TestWriteMetricsUnnamed(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := New([]Job{
+		{Pattern: "* * * * *", Exec: func() {}},
+		{Pattern: "0 * * * *", Exec: func() {}},
+		{Name: "Named", Pattern: "* * * * *", Exec: func() {}},
+	})
+
+	buf := &bytes.Buffer{}
+	if err := tab.WriteMetrics(buf); err != nil {
+		t.Fatalf("Unexpected error writing metrics: %s", err.Error())
+	}
+	metrics := buf.String()
+	if !strings.Contains(metrics, "cron_jobs 3\n") {
+		t.Errorf("Metrics did not count every job:\n%s", metrics)
+	}
+	if strings.Contains(metrics, `job=""`) {
+		t.Errorf("Metrics contained series for unnamed jobs:\n%s", metrics)
+	}
+	if !strings.Contains(metrics, `cron_job_runs_total{job="Named"} 0`) {
+		t.Errorf("Metrics did not contain named job:\n%s", metrics)
+	}
+}
@@ -0,0 +1,315 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxSearchYears is how far into the future to look for the next run of a job. Patterns such as Feb 29th on a
+// specific weekday can go many years between matches.
+const maxSearchYears = 10
+
+// maxAtSearchDays is how many days into the future to look for the next run of a job using At
+const maxAtSearchDays = 366
+
+// NextRun returns the first time after the given time that this job would run. The returned time is always at the
+// start of a minute (or second, for jobs using Seconds) and is in the same location as after. False is returned if the
+// pattern is invalid or would never run, or if the job runs at a fixed interval with Every. For jobs using At, the
+// days are in the location of after. For jobs with Locations, the earliest run in any of the locations is returned.
+func (job Job) NextRun(after time.Time) (time.Time, bool) {
+	if job.every() > 0 {
+		return time.Time{}, false
+	}
+	if err := job.Validate(); err != nil {
+		return time.Time{}, false
+	}
+	return job.nextRunFunc()(after)
+}
+
+// nextAt returns the first time after the given time that this job, which uses At, would run
+func (job Job) nextAt(after time.Time) (time.Time, bool) {
+	day := midnight(after)
+	for i := 0; i <= maxAtSearchDays; i++ {
+		target, ok := job.At(day.AddDate(0, 0, i))
+		if !ok {
+			continue
+		}
+		target = target.In(after.Location()).Truncate(time.Minute)
+		if target.After(after) {
+			return target, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// nextRunFunc returns a function that finds the first time after the given time that this job would run. This assumes
+// the job has already been validated and does not run at a fixed interval.
+func (job Job) nextRunFunc() func(after time.Time) (time.Time, bool) {
+	find := job.nextAt
+	if job.At == nil {
+		patterns := job.parse()
+		find = func(after time.Time) (time.Time, bool) {
+			return nextRun(patterns, after)
+		}
+	}
+	find = job.withinWindow(find)
+	if len(job.Locations) == 0 {
+		return find
+	}
+
+	// Find the earliest run in any of the locations
+	return func(after time.Time) (time.Time, bool) {
+		var earliest time.Time
+		found := false
+		for _, location := range job.Locations {
+			next, ok := find(after.In(location))
+			if ok && (!found || next.Before(earliest)) {
+				earliest = next
+				found = true
+			}
+		}
+		return earliest.In(after.Location()), found
+	}
+}
+
+// midnight returns the start of the day of the given time, in its location
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// DurationUntilNext returns how long from the given time until this job would next run. False is returned if the job
+// would never run.
+func (job Job) DurationUntilNext(from time.Time) (time.Duration, bool) {
+	next, ok := job.NextRun(from)
+	if !ok {
+		return 0, false
+	}
+	return next.Sub(from), true
+}
+
+// nextRun returns the earliest time after the given time that matches any of the patterns
+func nextRun(patterns []parsedPattern, after time.Time) (time.Time, bool) {
+	var earliest time.Time
+	found := false
+	for _, pattern := range patterns {
+		next, ok := nextMatchSeconds(pattern.components, pattern.seconds, after)
+		if ok && (!found || next.Before(earliest)) {
+			earliest = next
+			found = true
+		}
+	}
+	return earliest, found
+}
+
+// nextMatch returns the first minute after the given time that matches the pattern. Rather than checking every minute,
+// whole months, days, and hours are skipped when they can't possibly match.
+func nextMatch(pattern []string, after time.Time) (time.Time, bool) {
+	clock := after.Truncate(time.Minute).Add(time.Minute)
+	limit := clock.AddDate(maxSearchYears, 0, 0)
+	loc := clock.Location()
+
+	for clock.Before(limit) {
+		if !isItTime(pattern[3], int(clock.Month())) {
+			clock = time.Date(clock.Year(), clock.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !dateDoesMatch(pattern, clock) {
+			clock = time.Date(clock.Year(), clock.Month(), clock.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !isItTime(pattern[1], clock.Hour()) {
+			clock = time.Date(clock.Year(), clock.Month(), clock.Day(), clock.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !isItTime(pattern[0], clock.Minute()) {
+			clock = clock.Add(time.Minute)
+			continue
+		}
+		return clock, true
+	}
+
+	return time.Time{}, false
+}
+
+// RunsBetween returns every time between start and end (inclusive) that this job would run, in the same location as
+// start. Returns nil if the pattern is invalid or if the job runs at a fixed interval with Every.
+func (job Job) RunsBetween(start, end time.Time) []time.Time {
+	if job.every() > 0 {
+		return nil
+	}
+	if err := job.Validate(); err != nil {
+		return nil
+	}
+	find := job.nextRunFunc()
+
+	runs := []time.Time{}
+	clock := start.Add(-time.Nanosecond)
+	for {
+		next, ok := find(clock)
+		if !ok || next.After(end) {
+			break
+		}
+		runs = append(runs, next)
+		clock = next
+	}
+	return runs
+}
+
+// nextMatchSeconds returns the first time after the given time that matches the pattern and seconds component. If
+// seconds is empty then this is the same as nextMatch.
+func nextMatchSeconds(pattern []string, seconds string, after time.Time) (time.Time, bool) {
+	if seconds == "" {
+		return nextMatch(pattern, after)
+	}
+
+	minute := after.Truncate(time.Minute)
+	for {
+		// Find the first matching minute at or after the minute containing after
+		match, ok := nextMatch(pattern, minute.Add(-time.Nanosecond))
+		if !ok {
+			return time.Time{}, false
+		}
+		for second := 0; second < 60; second++ {
+			clock := match.Add(time.Duration(second) * time.Second)
+			if clock.After(after) && isItTime(seconds, second) {
+				return clock, true
+			}
+		}
+		minute = match.Add(time.Minute)
+	}
+}
+
+// NextRuns returns the next n times after the given time that this job would run. Fewer than n times are returned if
+// the job would stop running, and nil is returned if the pattern is invalid or if the job runs at a fixed interval with
+// Every.
+func (job Job) NextRuns(after time.Time, n int) []time.Time {
+	if job.every() > 0 || job.Validate() != nil {
+		return nil
+	}
+
+	next := job.RunIterator(after)
+	runs := []time.Time{}
+	for len(runs) < n {
+		run, ok := next()
+		if !ok {
+			break
+		}
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// RunIterator returns a function that yields each successive time after the given time that this job would run. The
+// next run is only computed when the function is called, so there is no limit to how many runs can be yielded. The
+// function returns false once there are no more runs, or if the pattern is invalid or the job runs at a fixed
+// interval with Every.
+func (job Job) RunIterator(after time.Time) func() (time.Time, bool) {
+	if job.every() > 0 || job.Validate() != nil {
+		return func() (time.Time, bool) {
+			return time.Time{}, false
+		}
+	}
+
+	find := job.nextRunFunc()
+	clock := after
+	done := false
+	return func() (time.Time, bool) {
+		if done {
+			return time.Time{}, false
+		}
+		next, ok := find(clock)
+		if !ok {
+			done = true
+			return time.Time{}, false
+		}
+		clock = next
+		return next, true
+	}
+}
+
+// NextRunDescription returns a human friendly description of when this job will next run after the given time, such
+// as "next run in 3 hours (today at 17:00)". The time is described in the location of from. If the job would never
+// run then "never" is returned.
+func (job Job) NextRunDescription(from time.Time) string {
+	next, ok := job.NextRun(from)
+	if !ok {
+		return "never"
+	}
+
+	return fmt.Sprintf("next run in %s (%s)", describeDuration(next.Sub(from)), describeDay(from, next))
+}
+
+// describeDuration returns a rough human friendly description of the duration, such as "3 hours"
+func describeDuration(d time.Duration) string {
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return fmt.Sprintf("1 %s", unit)
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+
+	if d < time.Minute {
+		return "less than a minute"
+	} else if d < time.Hour {
+		return plural(int(d/time.Minute), "minute")
+	} else if d < 24*time.Hour {
+		return plural(int(d/time.Hour), "hour")
+	}
+	return plural(int(d/(24*time.Hour)), "day")
+}
+
+// describeDay returns a human friendly description of the day and time of t relative to from, such as
+// "today at 17:00"
+func describeDay(from, t time.Time) string {
+	clock := t.Format("15:04")
+	fy, fm, fd := from.Date()
+	ty, tm, td := t.Date()
+	if fy == ty && fm == tm && fd == td {
+		return "today at " + clock
+	}
+	ny, nm, nd := from.AddDate(0, 0, 1).Date()
+	if ny == ty && nm == tm && nd == td {
+		return "tomorrow at " + clock
+	}
+	if fy == ty {
+		return t.Format("Monday, January 2") + " at " + clock
+	}
+	return t.Format("Monday, January 2 2006") + " at " + clock
+}
+
+// PatternsOverlap returns true if the two patterns will ever match the same minute, and the next time after now that
+// they both match. False is returned if either pattern is invalid, or if they won't match the same minute within the
+// next 10 years.
+func PatternsOverlap(a, b string) (bool, time.Time) {
+	return patternsOverlapAfter(a, b, time.Now())
+}
+
+func patternsOverlapAfter(a, b string, after time.Time) (bool, time.Time) {
+	jobA := Job{Pattern: a}
+	jobB := Job{Pattern: b}
+	if jobA.Validate() != nil || jobB.Validate() != nil {
+		return false, time.Time{}
+	}
+	patternsA := jobA.parse()
+	patternsB := jobB.parse()
+
+	// Leapfrog between the two patterns, each time jumping to the next run of one pattern at or after the latest run
+	// of the other, until they land on the same minute
+	limit := after.AddDate(maxSearchYears, 0, 0)
+	clock := after
+	for clock.Before(limit) {
+		nextA, ok := nextRun(patternsA, clock)
+		if !ok {
+			return false, time.Time{}
+		}
+		nextB, ok := nextRun(patternsB, nextA.Add(-time.Nanosecond))
+		if !ok {
+			return false, time.Time{}
+		}
+		if nextA.Equal(nextB) {
+			return true, nextA
+		}
+		clock = nextB.Add(-time.Nanosecond)
+	}
+	return false, time.Time{}
+}
@@ -0,0 +1,263 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestNextRun(t *testing.T) {
+	t.Parallel()
+
+	expect := func(pattern string, after time.Time, expected time.Time) {
+		next, ok := cron.Job{Pattern: pattern}.NextRun(after)
+		if !ok {
+			t.Errorf("No next run for pattern '%s' after '%s'", pattern, after)
+			return
+		}
+		if !next.Equal(expected) {
+			t.Errorf("Incorrect next run for pattern '%s' after '%s'. Got '%s' expected '%s'", pattern, after, next, expected)
+		}
+	}
+
+	expect("* * * * *", time.Date(2021, time.January, 1, 12, 0, 30, 0, time.UTC), time.Date(2021, time.January, 1, 12, 1, 0, 0, time.UTC))
+	expect("0 0 * * *", time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC), time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC))
+	expect("0 0 1 JAN *", time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC))
+	expect("*/15 9-17 * * *", time.Date(2021, time.January, 1, 17, 45, 0, 0, time.UTC), time.Date(2021, time.January, 2, 9, 0, 0, 0, time.UTC))
+	expect("0 0 29 2 *", time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC))
+
+	if _, ok := (cron.Job{Pattern: "0 0 31 2 *"}).NextRun(time.Now()); ok {
+		t.Errorf("Pattern that never runs should not have a next run")
+	}
+	if _, ok := (cron.Job{Pattern: "foo"}).NextRun(time.Now()); ok {
+		t.Errorf("Invalid pattern should not have a next run")
+	}
+}
+
+func TestDurationUntilNext(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2021, time.January, 1, 12, 0, 30, 0, time.UTC)
+
+	d, ok := cron.Job{Pattern: "* * * * *"}.DurationUntilNext(from)
+	if !ok {
+		t.Fatalf("No duration for all wildcard pattern")
+	}
+	if d != 30*time.Second {
+		t.Errorf("Incorrect duration for all wildcard pattern. Got %s expected %s", d, 30*time.Second)
+	}
+
+	d, ok = cron.Job{Pattern: "0 13 * * *"}.DurationUntilNext(from)
+	if !ok {
+		t.Fatalf("No duration for hourly pattern")
+	}
+	if d <= 0 {
+		t.Errorf("Duration should be positive, got %s", d)
+	}
+	if d != 59*time.Minute+30*time.Second {
+		t.Errorf("Incorrect duration for pattern. Got %s expected %s", d, 59*time.Minute+30*time.Second)
+	}
+
+	if _, ok := (cron.Job{Pattern: "0 0 30 2 *"}).DurationUntilNext(from); ok {
+		t.Errorf("Pattern that never runs should not have a duration")
+	}
+}
+
+func TestRunsBetween(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2021, time.January, 4, 0, 0, 0, 0, time.UTC) // A monday
+	end := start.AddDate(0, 0, 7)
+
+	runs := cron.Job{Pattern: "30 9 * * *"}.RunsBetween(start, end)
+	if len(runs) != 7 {
+		t.Fatalf("Unexpected number of runs for daily pattern. Expected %d got %d", 7, len(runs))
+	}
+	for i, run := range runs {
+		expected := time.Date(2021, time.January, 4+i, 9, 30, 0, 0, time.UTC)
+		if !run.Equal(expected) {
+			t.Errorf("Unexpected run %d. Got '%s' expected '%s'", i, run, expected)
+		}
+	}
+
+	// Both the start and end are inclusive
+	runs = cron.Job{Pattern: "0 0 * * *"}.RunsBetween(start, end)
+	if len(runs) != 8 {
+		t.Errorf("Unexpected number of runs for midnight pattern. Expected %d got %d", 8, len(runs))
+	}
+
+	// Day of month and day of week are OR-d: the 5th (a tuesday) or any friday
+	runs = cron.Job{Pattern: "0 0 5 * FRI"}.RunsBetween(start, end)
+	if len(runs) != 2 {
+		t.Fatalf("Unexpected number of runs for OR-d pattern. Expected %d got %d", 2, len(runs))
+	}
+	if runs[0].Day() != 5 || runs[1].Weekday() != time.Friday {
+		t.Errorf("Unexpected runs for OR-d pattern: %v", runs)
+	}
+
+	if runs := (cron.Job{Pattern: "0 0 31 2 *"}).RunsBetween(start, end); len(runs) != 0 {
+		t.Errorf("Unexpected runs for pattern that never runs: %v", runs)
+	}
+}
+
+func TestNextRunSeconds(t *testing.T) {
+	t.Parallel()
+
+	job := cron.Job{Pattern: "*/15 0 * * * *", Seconds: true}
+	after := time.Date(2021, time.January, 1, 12, 0, 50, 0, time.UTC)
+	next, ok := job.NextRun(after)
+	if !ok {
+		t.Fatalf("No next run for seconds pattern")
+	}
+	expected := time.Date(2021, time.January, 1, 13, 0, 0, 0, time.UTC)
+	if !next.Equal(expected) {
+		t.Errorf("Incorrect next run for seconds pattern. Got '%s' expected '%s'", next, expected)
+	}
+
+	runs := job.RunsBetween(expected, expected.Add(time.Minute))
+	if len(runs) != 4 {
+		t.Errorf("Unexpected number of runs for seconds pattern. Expected %d got %d", 4, len(runs))
+	}
+}
+
+func TestRunIterator(t *testing.T) {
+	t.Parallel()
+
+	job := cron.Job{Pattern: "*/20 9-10 * * *"}
+	after := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	expected := job.NextRuns(after, 10)
+	if len(expected) != 10 {
+		t.Fatalf("Unexpected number of next runs. Expected %d got %d", 10, len(expected))
+	}
+
+	next := job.RunIterator(after)
+	for i, run := range expected {
+		result, ok := next()
+		if !ok {
+			t.Fatalf("Iterator stopped early at run %d", i)
+		}
+		if !result.Equal(run) {
+			t.Errorf("Unexpected run %d from iterator. Got '%s' expected '%s'", i, result, run)
+		}
+	}
+
+	// 9:00, 9:20, 9:40, 10:00, 10:20, 10:40 then 9:00 the next day
+	if !expected[6].Equal(time.Date(2021, time.January, 2, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected 7th run '%s'", expected[6])
+	}
+
+	next = cron.Job{Pattern: "0 0 31 2 *"}.RunIterator(after)
+	if _, ok := next(); ok {
+		t.Errorf("Iterator yielded a run for a pattern that never runs")
+	}
+	if _, ok := next(); ok {
+		t.Errorf("Iterator yielded a run after it was exhausted")
+	}
+}
+
+func TestNextRunDescription(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2021, time.January, 1, 14, 0, 0, 0, time.UTC) // A friday
+
+	expect := func(pattern string, expected string) {
+		result := cron.Job{Pattern: pattern}.NextRunDescription(from)
+		if result != expected {
+			t.Errorf("Incorrect description for pattern '%s'. Got '%s' expected '%s'", pattern, result, expected)
+		}
+	}
+
+	expect("0 17 * * *", "next run in 3 hours (today at 17:00)")
+	expect("* * * * *", "next run in 1 minute (today at 14:01)")
+	expect("30 14 * * *", "next run in 30 minutes (today at 14:30)")
+	expect("0 9 * * *", "next run in 19 hours (tomorrow at 09:00)")
+	expect("0 9 * * MON", "next run in 2 days (Monday, January 4 at 09:00)")
+	expect("0 0 1 1 *", "next run in 364 days (Saturday, January 1 2022 at 00:00)")
+	expect("0 0 31 2 *", "never")
+}
+
+func TestLeapDay(t *testing.T) {
+	t.Parallel()
+
+	year := func(y int) (time.Time, time.Time) {
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(y, time.December, 31, 23, 59, 0, 0, time.UTC)
+	}
+
+	leapDay := cron.Job{Pattern: "0 0 29 2 *"}
+	if runs := leapDay.RunsBetween(year(2023)); len(runs) != 0 {
+		t.Errorf("Leap day pattern ran in a non-leap year: %v", runs)
+	}
+	if runs := leapDay.RunsBetween(year(2024)); len(runs) != 1 || !runs[0].Equal(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected runs of leap day pattern in a leap year: %v", runs)
+	}
+	// 2100 is not a leap year
+	if next, _ := leapDay.NextRun(time.Date(2097, time.January, 1, 0, 0, 0, 0, time.UTC)); !next.Equal(time.Date(2104, time.February, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected next run of leap day pattern %s", next)
+	}
+	if !leapDay.WouldRunNow(cron.WithTime(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC))) {
+		t.Errorf("Leap day pattern should run on the leap day")
+	}
+	if leapDay.WouldRunNow(cron.WithTime(time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC))) {
+		t.Errorf("Leap day pattern should not run on the day after February 28th in a non-leap year")
+	}
+
+	// With a day of week the two are OR-d, so the job runs on every Sunday in February as well as on the leap day
+	leapDayOrSunday := cron.Job{Pattern: "0 0 29 2 0"}
+	if runs := leapDayOrSunday.RunsBetween(year(2023)); len(runs) != 4 {
+		t.Errorf("Unexpected runs of leap day or Sunday pattern in a non-leap year: %v", runs)
+	}
+	runs := leapDayOrSunday.RunsBetween(year(2024))
+	if len(runs) != 5 || !runs[4].Equal(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected runs of leap day or Sunday pattern in a leap year: %v", runs)
+	}
+	for _, run := range runs {
+		if run.Month() != time.February {
+			t.Errorf("Leap day or Sunday pattern ran outside of February: %s", run)
+		}
+	}
+}
+
+func TestAtResolver(t *testing.T) {
+	t.Parallel()
+
+	// A stub for something like sunset, which runs at 18:30:45 every day except Sundays
+	job := cron.Job{
+		Name: "Sunset",
+		At: func(day time.Time) (time.Time, bool) {
+			if day.Weekday() == time.Sunday {
+				return time.Time{}, false
+			}
+			return day.Add(18*time.Hour + 30*time.Minute + 45*time.Second), true
+		},
+	}
+	if err := job.Validate(); err != nil {
+		t.Fatalf("Unexpected error validating job: %s", err.Error())
+	}
+
+	friday := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	expectRun := func(at time.Time, expected bool) {
+		if actual := job.WouldRunNow(cron.WithTime(at)); actual != expected {
+			t.Errorf("Unexpected result for %s. Expected %v got %v", at, expected, actual)
+		}
+	}
+	expectRun(friday.Add(18*time.Hour+30*time.Minute), true)
+	expectRun(friday.Add(18*time.Hour+30*time.Minute+59*time.Second), true)
+	expectRun(friday.Add(18*time.Hour+31*time.Minute), false)
+	expectRun(friday.AddDate(0, 0, 2).Add(18*time.Hour+30*time.Minute), false)
+
+	next, ok := job.NextRun(friday.Add(19 * time.Hour))
+	if !ok || !next.Equal(time.Date(2021, time.January, 2, 18, 30, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected next run %s", next)
+	}
+	runs := job.RunsBetween(friday, friday.AddDate(0, 0, 7))
+	if len(runs) != 6 {
+		t.Errorf("Unexpected number of runs in a week. Expected %d got %d: %v", 6, len(runs), runs)
+	}
+
+	job.Every = time.Hour
+	if err := job.Validate(); err == nil {
+		t.Errorf("No error seen for job using both At and Every")
+	}
+}
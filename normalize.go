@@ -0,0 +1,160 @@
+package cron
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Normalize returns the canonical form of the given pattern, so that two patterns that mean the same thing will
+// normalize to the same string. Named values are converted to their numerical values, list elements are sorted and
+// deduplicated, leading zeros are removed, single-value ranges are collapsed, and whitespace is reduced to a single
+// space between components. Fixed interval patterns are normalized to the canonical duration, such as @every 1h30m0s.
+// An error is returned if the pattern is not valid.
+func Normalize(pattern string) (string, error) {
+	pattern = strings.Join(strings.Fields(pattern), " ")
+	job := Job{Pattern: pattern}
+	if err := job.Validate(); err != nil {
+		return "", err
+	}
+	if every := job.every(); every > 0 {
+		return everyPrefix + every.String(), nil
+	}
+
+	components := getRealPattern(pattern)
+	for i, component := range components {
+		components[i] = normalizeComponent(component)
+	}
+
+	return strings.Join(components, " "), nil
+}
+
+func normalizeComponent(component string) string {
+	if !strings.ContainsRune(component, ',') {
+		return normalizeElement(component)
+	}
+
+	elements := []string{}
+	seen := map[string]bool{}
+	for _, element := range strings.Split(component, ",") {
+		element = normalizeElement(element)
+		if seen[element] {
+			continue
+		}
+		seen[element] = true
+		elements = append(elements, element)
+	}
+	sort.SliceStable(elements, func(i, j int) bool {
+		left := elementStart(elements[i])
+		right := elementStart(elements[j])
+		if left == right {
+			return elements[i] < elements[j]
+		}
+		return left < right
+	})
+
+	return strings.Join(elements, ",")
+}
+
+// normalizeElement removes leading zeros from the values of the element and collapses single-value ranges into the
+// value
+func normalizeElement(element string) string {
+	step := ""
+	if idx := strings.IndexRune(element, '/'); idx >= 0 {
+		step = "/" + normalizeValue(element[idx+1:])
+		element = element[:idx]
+	}
+
+	parts := strings.Split(element, "-")
+	if len(parts) != 2 {
+		return normalizeValue(element) + step
+	}
+	left := normalizeValue(parts[0])
+	right := normalizeValue(parts[1])
+	if left == right && step == "" {
+		return left
+	}
+	return left + "-" + right + step
+}
+
+// normalizeValue removes any leading zeros from a numerical value. Values that are not numbers are returned as-is.
+func normalizeValue(value string) string {
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return value
+	}
+	return strconv.Itoa(number)
+}
+
+// elementStart returns the first value an element could match, used for sorting list elements
+func elementStart(element string) int {
+	if strings.HasPrefix(element, "*") {
+		return -1
+	}
+	if idx := strings.IndexAny(element, "-/"); idx >= 0 {
+		element = element[:idx]
+	}
+	value, _ := strconv.Atoi(element)
+	return value
+}
+
+// Equal returns true if both tabs schedule the same jobs, regardless of the order of the jobs. Jobs are compared by
+// their name and their normalized patterns, so patterns that mean the same thing, such as 0,30 * * * * and
+// 30,0 * * * *, are equal. The methods of the jobs and the state and settings of the tabs are not compared. Jobs using
+// At are only compared by name, as the method that computes their time can't be compared.
+func (s *Tab) Equal(other *Tab) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+	if len(s.Jobs) != len(other.Jobs) {
+		return false
+	}
+
+	counts := map[string]int{}
+	for _, job := range s.Jobs {
+		counts[job.Name+"\n"+job.schedule()]++
+	}
+	for _, job := range other.Jobs {
+		counts[job.Name+"\n"+job.schedule()]--
+	}
+	for _, count := range counts {
+		if count != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// schedule returns a description of when this job runs, where jobs that run at the same times have the same
+// description
+func (job Job) schedule() string {
+	if every := job.every(); every > 0 {
+		return everyPrefix + every.String()
+	}
+	if job.At != nil {
+		return "@at"
+	}
+
+	patterns := []string{}
+	seen := map[string]bool{}
+	for _, pattern := range job.patternStrings() {
+		seconds := ""
+		if job.Seconds {
+			if s, rest, err := splitSeconds(strings.TrimSpace(pattern)); err == nil {
+				seconds = normalizeComponent(s) + " "
+				pattern = rest
+			}
+		}
+		if normalized, err := Normalize(pattern); err == nil {
+			pattern = normalized
+		}
+		pattern = seconds + pattern
+		if seen[pattern] {
+			continue
+		}
+		seen[pattern] = true
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	return strings.Join(patterns, "\n")
+}
@@ -0,0 +1,94 @@
+package cron_test
+
+import (
+	"testing"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	expect := func(pattern string, expected string) {
+		result, err := cron.Normalize(pattern)
+		if err != nil {
+			t.Errorf("Unexpected error normalizing pattern '%s': %s", pattern, err.Error())
+			return
+		}
+		if result != expected {
+			t.Errorf("Incorrect normalized pattern for '%s'. Got '%s' expected '%s'", pattern, result, expected)
+		}
+	}
+
+	expect("* * * * *", "* * * * *")
+	expect("5,1 * * * *", "1,5 * * * *")
+	expect("1,5 * * * *", "1,5 * * * *")
+	expect("5,1,5 * * * *", "1,5 * * * *")
+	expect("0  0   *  * *", "0 0 * * *")
+	expect("0 0 * * FRI", "0 0 * * 5")
+	expect("0 0 1 JAN *", "0 0 1 1 *")
+	expect("*/5 9-17 * * *", "*/5 9-17 * * *")
+	expect("30,1-5,10 * * * *", "1-5,10,30 * * * *")
+	expect("45,*/15 * * * *", "*/15,45 * * * *")
+	expect("5-5 * * * *", "5 * * * *")
+	expect("0 9-9,12 * * *", "0 9,12 * * *")
+	expect("05 * * * *", "5 * * * *")
+	expect("00 09-017/02 * * *", "0 9-17/2 * * *")
+	expect("5,05 * * * *", "5 * * * *")
+	expect("@every 90s", "@every 1m30s")
+	expect("@every   1h30m", "@every 1h30m0s")
+
+	if _, err := cron.Normalize("foo"); err == nil {
+		t.Errorf("No error seen normalizing invalid pattern")
+	}
+}
+
+func TestTabEqual(t *testing.T) {
+	t.Parallel()
+
+	newTab := func(jobs ...cron.Job) *cron.Tab {
+		tab, err := cron.New(jobs)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+		return tab
+	}
+
+	original := newTab(
+		cron.Job{Name: "Report", Pattern: "0,30 9-17 * * MON-FRI", Exec: func() {}},
+		cron.Job{Name: "Cleanup", Pattern: "0 0 1 JAN *"},
+	)
+	reordered := newTab(
+		cron.Job{Name: "Cleanup", Pattern: "0 0 1 1 *"},
+		cron.Job{Name: "Report", Pattern: "30,0 9-17 * * 1-5"},
+	)
+	if !original.Equal(reordered) || !reordered.Equal(original) {
+		t.Errorf("Tabs with equivalent jobs should be equal")
+	}
+	if !original.Equal(original.Clone()) {
+		t.Errorf("Tab should be equal to its clone")
+	}
+
+	changed := newTab(
+		cron.Job{Name: "Report", Pattern: "0,15 9-17 * * MON-FRI"},
+		cron.Job{Name: "Cleanup", Pattern: "0 0 1 JAN *"},
+	)
+	if original.Equal(changed) {
+		t.Errorf("Tabs with a changed pattern should not be equal")
+	}
+	renamed := newTab(
+		cron.Job{Name: "Reports", Pattern: "0,30 9-17 * * MON-FRI"},
+		cron.Job{Name: "Cleanup", Pattern: "0 0 1 JAN *"},
+	)
+	if original.Equal(renamed) {
+		t.Errorf("Tabs with a renamed job should not be equal")
+	}
+	if original.Equal(newTab(cron.Job{Name: "Cleanup", Pattern: "0 0 1 JAN *"})) {
+		t.Errorf("Tabs with a different number of jobs should not be equal")
+	}
+
+	every := newTab(cron.Job{Name: "Poll", Pattern: "@every 90m"})
+	if !every.Equal(newTab(cron.Job{Name: "Poll", Pattern: "@every 1h30m"})) {
+		t.Errorf("Tabs with equivalent intervals should be equal")
+	}
+}
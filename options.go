@@ -0,0 +1,45 @@
+package cron
+
+import (
+	"time"
+)
+
+// MatchOption changes how a job is matched by WouldRunNow
+type MatchOption func(o *matchOptions)
+
+type matchOptions struct {
+	time     *time.Time
+	location *time.Location
+}
+
+// WithTime evaluates the job at the given time instead of the current time. The time is evaluated in its own location
+// unless WithLocation is also provided.
+func WithTime(t time.Time) MatchOption {
+	return func(o *matchOptions) {
+		o.time = &t
+	}
+}
+
+// WithLocation evaluates the job in the given timezone instead of the local timezone
+func WithLocation(loc *time.Location) MatchOption {
+	return func(o *matchOptions) {
+		o.location = loc
+	}
+}
+
+// matchTime returns the time to evaluate with the given options applied
+func matchTime(options []MatchOption) time.Time {
+	o := matchOptions{}
+	for _, option := range options {
+		option(&o)
+	}
+
+	clock := time.Now().In(time.Local)
+	if o.time != nil {
+		clock = *o.time
+	}
+	if o.location != nil {
+		clock = clock.In(o.location)
+	}
+	return clock
+}
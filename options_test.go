@@ -0,0 +1,105 @@
+package cron_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestWouldRunNowOptions(t *testing.T) {
+	t.Parallel()
+
+	job := cron.Job{Pattern: "30 9 * * *"}
+	at := time.Date(2021, time.January, 1, 9, 30, 0, 0, time.UTC)
+
+	if !job.WouldRunNow(cron.WithTime(at)) {
+		t.Errorf("Job should run at the provided time")
+	}
+	if job.WouldRunNow(cron.WithTime(at.Add(time.Minute))) {
+		t.Errorf("Job should not run a minute after the provided time")
+	}
+
+	tokyo := time.FixedZone("Tokyo", 9*60*60)
+	if job.WouldRunNow(cron.WithTime(at), cron.WithLocation(tokyo)) {
+		t.Errorf("Job should not run at the provided time in another timezone")
+	}
+	if !job.WouldRunNow(cron.WithTime(at.Add(-9*time.Hour)), cron.WithLocation(tokyo)) {
+		t.Errorf("Job should run at the provided time in another timezone")
+	}
+
+	// The current minute in a given zone
+	now := time.Now().In(tokyo)
+	job = cron.Job{Pattern: fmt.Sprintf("* %d * * *", now.Hour())}
+	if now.Minute() != 59 && !job.WouldRunNow(cron.WithLocation(tokyo)) {
+		t.Errorf("Job should run now in the provided timezone")
+	}
+}
+
+func TestMatchVariantsAgree(t *testing.T) {
+	t.Parallel()
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	patterns := []string{"* * * * *", "0 * * * *", "30 9 * * MON-FRI", "0 0 1 JAN *", "*/15 0-12 * * *", "0 21 * * SUN"}
+	start := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for _, pattern := range patterns {
+		job := cron.Job{Name: "Job", Pattern: pattern}
+		tab, _ := cron.New([]cron.Job{job})
+		tab.TZ = tokyo
+
+		for clock := start; clock.Before(start.Add(8 * 24 * time.Hour)); clock = clock.Add(15 * time.Minute) {
+			local := clock.In(tokyo)
+			expected := job.WouldRunNow(cron.WithTime(local))
+			if result := job.WouldRunNow(cron.WithTime(clock), cron.WithLocation(tokyo)); result != expected {
+				t.Fatalf("WouldRunNow with location disagrees for pattern '%s' at %s", pattern, local)
+			}
+			if result := len(tab.DueAt(clock)) == 1; result != expected {
+				t.Fatalf("DueAt disagrees for pattern '%s' at %s", pattern, local)
+			}
+			if result := job.MatchDetail(local).Matches; result != expected {
+				t.Fatalf("MatchDetail disagrees for pattern '%s' at %s", pattern, local)
+			}
+		}
+	}
+
+	every := cron.Job{Pattern: "@every 1m"}
+	if every.WouldRunNow() || every.WouldRunNowInTZ(tokyo) {
+		t.Errorf("Fixed interval job should never match")
+	}
+}
+
+func TestWouldRunNowLocation(t *testing.T) {
+	t.Parallel()
+
+	// 23:30 on Friday in UTC is 01:30 on Saturday in UTC+2
+	plusTwo := time.FixedZone("UTC+2", 2*60*60)
+	at := time.Date(2021, time.January, 1, 23, 30, 0, 0, time.UTC)
+	friday := cron.Job{Pattern: "30 * * * 5"}
+	saturday := cron.Job{Pattern: "30 * * * 6"}
+
+	if !friday.WouldRunNow(cron.WithTime(at)) || saturday.WouldRunNow(cron.WithTime(at)) {
+		t.Errorf("UTC time should be matched against the UTC day")
+	}
+	if friday.WouldRunNow(cron.WithTime(at), cron.WithLocation(plusTwo)) || !saturday.WouldRunNow(cron.WithTime(at), cron.WithLocation(plusTwo)) {
+		t.Errorf("Time should be matched against the day in the provided location")
+	}
+}
+
+func TestWouldRunNowLocal(t *testing.T) {
+	t.Parallel()
+
+	for attempt := 0; attempt < 3; attempt++ {
+		now := time.Now().In(time.Local)
+		job := cron.Job{Pattern: fmt.Sprintf("%d %d %d %d %d", now.Minute(), now.Hour(), now.Day(), now.Month(), now.Weekday())}
+		result := job.WouldRunNowLocal()
+		if time.Now().In(time.Local).Minute() != now.Minute() {
+			// The minute changed while evaluating, try again
+			continue
+		}
+		if !result {
+			t.Errorf("Job for the current local time should run now")
+		}
+		return
+	}
+}
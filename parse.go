@@ -0,0 +1,182 @@
+package cron
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ExecFactory returns the method to invoke for the given crontab command. Variables contains every `KEY=VALUE`
+// assignment that appeared in the crontab before the line containing the command.
+type ExecFactory func(command string, variables map[string]string) func()
+
+var variablePattern = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.*)$`)
+
+// ParseFile will read the crontab file at the given path and return a new tab for the jobs it contains.
+// See Parse for details on the file format.
+func ParseFile(filePath string, factory ExecFactory) (*Tab, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f, factory)
+}
+
+// commandTag is the tag that holds the command of a job parsed from a crontab, when it differs from the job's name
+const commandTag = "command"
+
+// Parse will read crontab lines from r and return a new tab for the jobs it contains, but do not start it.
+//
+// Each job line must contain the 5 pattern components followed by the command. The command is used as the name of the
+// job and is passed to factory to get the method to invoke. If the same command appears on more than one line, the
+// later jobs are named after the command and their line number, such as "/usr/bin/backup.sh (line 4)", and the command
+// is kept in the "command" tag of the job. Blank lines and lines starting with # are ignored. Lines
+// in the form of `KEY=VALUE` are collected into the Variables map of the returned tab, with surrounding quotes removed
+// from the value.
+func Parse(r io.Reader, factory ExecFactory) (*Tab, error) {
+	variables := map[string]string{}
+	jobs := []Job{}
+	commands := map[string]bool{}
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if match := variablePattern.FindStringSubmatch(line); match != nil {
+			variables[match[1]] = unquoteVariable(match[2])
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 6 {
+			return nil, fmt.Errorf("line %d: missing command", lineNumber)
+		}
+		command := strings.Join(fields[5:], " ")
+		job := Job{
+			Pattern: strings.Join(fields[0:5], " "),
+			Name:    command,
+		}
+		if commands[command] {
+			// Job names must be unique, so repeated commands are named after their line
+			job.Name = fmt.Sprintf("%s (line %d)", command, lineNumber)
+			job.Tags = map[string]string{commandTag: command}
+		}
+		commands[command] = true
+		if err := job.Validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		job.Exec = factory(command, copyStringMap(variables))
+		jobs = append(jobs, job)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	tab, err := New(jobs)
+	if err != nil {
+		return nil, err
+	}
+	tab.Variables = variables
+	return tab, nil
+}
+
+// WriteCrontab will write the jobs of this tab to w in the crontab format read by Parse, so that the tab can be
+// round-tripped through a file. Variables are written first, sorted by name, followed by one line per job with its
+// normalized pattern and its name as the command, or its "command" tag if it has one. The description of a job is
+// written as a comment before it.
+//
+// Jobs that can't be represented in a crontab, such as jobs using Every, At, or Seconds, or additional patterns of a
+// job, are written as comments so that they are not lost but are ignored by Parse.
+func (s *Tab) WriteCrontab(w io.Writer) error {
+	keys := make([]string, 0, len(s.Variables))
+	for key := range s.Variables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, err := fmt.Fprintf(w, "%s=%s\n", key, quoteVariable(s.Variables[key])); err != nil {
+			return err
+		}
+	}
+	if len(keys) > 0 {
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+
+	for _, job := range s.Jobs {
+		command := job.Name
+		if tagged, ok := job.Tags[commandTag]; ok {
+			command = tagged
+		}
+		if job.Description != "" {
+			if _, err := fmt.Fprintf(w, "# %s\n", job.Description); err != nil {
+				return err
+			}
+		}
+		if every := job.every(); every > 0 {
+			if _, err := fmt.Fprintf(w, "# %s%s %s\n", everyPrefix, every, job.Name); err != nil {
+				return err
+			}
+			continue
+		}
+		if job.At != nil {
+			if _, err := fmt.Fprintf(w, "# %s runs at a computed time\n", job.Name); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for i, pattern := range job.patternStrings() {
+			prefix := ""
+			if job.Seconds || i > 0 {
+				prefix = "# "
+			} else if normalized, err := Normalize(pattern); err == nil {
+				pattern = normalized
+			}
+			if _, err := fmt.Fprintf(w, "%s%s %s\n", prefix, pattern, command); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// quoteVariable adds quotes to the value of a variable if it would not otherwise be read back the same
+func quoteVariable(value string) string {
+	if value != strings.TrimSpace(value) || strings.ContainsAny(value, "\"'#") {
+		return "\"" + value + "\""
+	}
+	return value
+}
+
+func unquoteVariable(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 {
+		first := value[0]
+		last := value[len(value)-1]
+		if (first == '"' || first == '\'') && first == last {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
+
+func copyStringMap(variables map[string]string) map[string]string {
+	c := make(map[string]string, len(variables))
+	for k, v := range variables {
+		c[k] = v
+	}
+	return c
+}
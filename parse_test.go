@@ -0,0 +1,194 @@
+package cron_test
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	crontab := `# Example crontab
+SHELL=/bin/sh
+MAILTO = "admin@example.com"
+
+0 2 * * * /usr/local/bin/backup $SHELL
+*/5 * * * * /usr/local/bin/poll
+`
+
+	seen := map[string]map[string]string{}
+	tab, err := cron.Parse(strings.NewReader(crontab), func(command string, variables map[string]string) func() {
+		seen[command] = variables
+		return func() {}
+	})
+	if err != nil {
+		t.Fatalf("Error parsing crontab: %s", err.Error())
+	}
+
+	if len(tab.Jobs) != 2 {
+		t.Fatalf("Unexpected number of jobs. Expected %d got %d", 2, len(tab.Jobs))
+	}
+	if tab.Jobs[0].Pattern != "0 2 * * *" || tab.Jobs[0].Name != "/usr/local/bin/backup $SHELL" {
+		t.Errorf("Unexpected first job: '%s' '%s'", tab.Jobs[0].Pattern, tab.Jobs[0].Name)
+	}
+	if tab.Jobs[1].Pattern != "*/5 * * * *" || tab.Jobs[1].Name != "/usr/local/bin/poll" {
+		t.Errorf("Unexpected second job: '%s' '%s'", tab.Jobs[1].Pattern, tab.Jobs[1].Name)
+	}
+	if tab.Variables["SHELL"] != "/bin/sh" {
+		t.Errorf("Unexpected SHELL variable '%s'", tab.Variables["SHELL"])
+	}
+	if tab.Variables["MAILTO"] != "admin@example.com" {
+		t.Errorf("Unexpected MAILTO variable '%s'", tab.Variables["MAILTO"])
+	}
+	if seen["/usr/local/bin/poll"]["SHELL"] != "/bin/sh" {
+		t.Errorf("Variables not passed to exec factory")
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	t.Parallel()
+
+	factory := func(command string, variables map[string]string) func() {
+		return func() {}
+	}
+
+	if _, err := cron.Parse(strings.NewReader("0 2 * * *\n"), factory); err == nil {
+		t.Errorf("No error seen for job without command")
+	}
+	if _, err := cron.Parse(strings.NewReader("0 25 * * * /bin/true\n"), factory); err == nil {
+		t.Errorf("No error seen for job with invalid pattern")
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	t.Parallel()
+
+	filePath := path.Join(t.TempDir(), "crontab")
+	if err := os.WriteFile(filePath, []byte("FOO=bar\n0 0 * * * /bin/true\n"), 0644); err != nil {
+		t.Fatalf("Error writing crontab: %s", err.Error())
+	}
+
+	tab, err := cron.ParseFile(filePath, func(command string, variables map[string]string) func() {
+		return func() {}
+	})
+	if err != nil {
+		t.Fatalf("Error parsing crontab: %s", err.Error())
+	}
+	if len(tab.Jobs) != 1 {
+		t.Errorf("Unexpected number of jobs. Expected %d got %d", 1, len(tab.Jobs))
+	}
+	if tab.Variables["FOO"] != "bar" {
+		t.Errorf("Unexpected FOO variable '%s'", tab.Variables["FOO"])
+	}
+}
+
+func TestWriteCrontab(t *testing.T) {
+	t.Parallel()
+
+	crontab := `SHELL=/bin/sh
+MAILTO = " admin@example.com"
+
+0 2 * * * /usr/local/bin/backup $SHELL
+*/5 * * * mon-fri /usr/local/bin/poll
+`
+	factory := func(command string, variables map[string]string) func() {
+		return func() {}
+	}
+	tab, err := cron.Parse(strings.NewReader(crontab), factory)
+	if err != nil {
+		t.Fatalf("Error parsing crontab: %s", err.Error())
+	}
+	tab.Jobs[0].Description = "Nightly backup"
+
+	written := &strings.Builder{}
+	if err := tab.WriteCrontab(written); err != nil {
+		t.Fatalf("Error writing crontab: %s", err.Error())
+	}
+	expected := `MAILTO=" admin@example.com"
+SHELL=/bin/sh
+
+# Nightly backup
+0 2 * * * /usr/local/bin/backup $SHELL
+*/5 * * * 1-5 /usr/local/bin/poll
+`
+	if written.String() != expected {
+		t.Errorf("Unexpected crontab.\nExpected:\n%s\nGot:\n%s", expected, written.String())
+	}
+
+	parsed, err := cron.Parse(strings.NewReader(written.String()), factory)
+	if err != nil {
+		t.Fatalf("Error parsing written crontab: %s", err.Error())
+	}
+	if len(parsed.Jobs) != len(tab.Jobs) {
+		t.Fatalf("Unexpected number of jobs after round trip. Expected %d got %d", len(tab.Jobs), len(parsed.Jobs))
+	}
+	for i, job := range parsed.Jobs {
+		original, _ := cron.Normalize(tab.Jobs[i].Pattern)
+		if job.Pattern != original || job.Name != tab.Jobs[i].Name {
+			t.Errorf("Job changed after round trip. Expected '%s %s' got '%s %s'", original, tab.Jobs[i].Name, job.Pattern, job.Name)
+		}
+	}
+	if parsed.Variables["MAILTO"] != " admin@example.com" || parsed.Variables["SHELL"] != "/bin/sh" {
+		t.Errorf("Variables changed after round trip: %v", parsed.Variables)
+	}
+}
+
+func TestWriteCrontabUnsupported(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := cron.New([]cron.Job{
+		{Name: "heartbeat", Every: time.Minute},
+		{Name: "precise", Pattern: "30 0 * * * *", Seconds: true},
+		{Name: "twice", Pattern: "0 9 * * *", Patterns: []string{"0 17 * * *"}},
+	})
+
+	written := &strings.Builder{}
+	if err := tab.WriteCrontab(written); err != nil {
+		t.Fatalf("Error writing crontab: %s", err.Error())
+	}
+	expected := `# @every 1m0s heartbeat
+# 30 0 * * * * precise
+0 9 * * * twice
+# 0 17 * * * twice
+`
+	if written.String() != expected {
+		t.Errorf("Unexpected crontab.\nExpected:\n%s\nGot:\n%s", expected, written.String())
+	}
+}
+
+func TestParseRepeatedCommand(t *testing.T) {
+	t.Parallel()
+
+	crontab := `0 1 * * * /usr/bin/backup.sh
+0 13 * * * /usr/bin/backup.sh
+`
+
+	commands := []string{}
+	tab, err := cron.Parse(strings.NewReader(crontab), func(command string, variables map[string]string) func() {
+		commands = append(commands, command)
+		return func() {}
+	})
+	if err != nil {
+		t.Fatalf("Error parsing crontab: %s", err.Error())
+	}
+	if len(tab.Jobs) != 2 || tab.Jobs[0].Name != "/usr/bin/backup.sh" || tab.Jobs[1].Name != "/usr/bin/backup.sh (line 2)" {
+		t.Fatalf("Unexpected jobs %+v", tab.Jobs)
+	}
+	if len(commands) != 2 || commands[0] != commands[1] {
+		t.Errorf("Unexpected commands passed to exec factory %v", commands)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := tab.WriteCrontab(buf); err != nil {
+		t.Fatalf("Error writing crontab: %s", err.Error())
+	}
+	if buf.String() != crontab {
+		t.Errorf("Unexpected crontab written.\nExpected:\n%s\nGot:\n%s", crontab, buf.String())
+	}
+}
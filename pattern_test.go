@@ -52,6 +52,19 @@ func TestPatternDoesMatch(t *testing.T) {
 	expect(true, "0 3,5,7 * * *", time.Date(2021, time.January, 1, 7, 0, 0, 0, time.UTC))
 	expect(false, "0 3,5,7 * * *", time.Date(2021, time.January, 1, 3, 1, 0, 0, time.UTC))
 	expect(false, "0 3,5,7 * * *", time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC))
+
+	// Lists containing ranges and expressions
+	expect(true, "1-5,10 * * * *", time.Date(2021, time.January, 1, 0, 3, 0, 0, time.UTC))
+	expect(true, "1-5,10 * * * *", time.Date(2021, time.January, 1, 0, 10, 0, 0, time.UTC))
+	expect(false, "1-5,10 * * * *", time.Date(2021, time.January, 1, 0, 7, 0, 0, time.UTC))
+	expect(true, "*/15,7 * * * *", time.Date(2021, time.January, 1, 0, 45, 0, 0, time.UTC))
+	expect(true, "*/15,7 * * * *", time.Date(2021, time.January, 1, 0, 7, 0, 0, time.UTC))
+	expect(false, "*/15,7 * * * *", time.Date(2021, time.January, 1, 0, 8, 0, 0, time.UTC))
+
+	// Steps applied to a range count from the start of the range
+	expect(true, "0 1-10/3 * * *", time.Date(2021, time.January, 1, 4, 0, 0, 0, time.UTC))
+	expect(false, "0 1-10/3 * * *", time.Date(2021, time.January, 1, 3, 0, 0, 0, time.UTC))
+	expect(false, "0 1-10/3 * * *", time.Date(2021, time.January, 1, 13, 0, 0, 0, time.UTC))
 }
 
 func TestJobWouldRunNow(t *testing.T) {
@@ -85,3 +98,500 @@ func TestWouldRunNowInTZ(t *testing.T) {
 		t.Errorf("Incorrect WouldRunNowInTZ result for all wildcard pattern")
 	}
 }
+
+func TestSecondsStepMatch(t *testing.T) {
+	t.Parallel()
+
+	job := Job{Pattern: "*/15 * * * * *", Seconds: true}
+	matches := []int{}
+	for second := 0; second < 60; second++ {
+		if job.matchAt(time.Date(2021, time.January, 1, 12, 0, second, 0, time.UTC)) {
+			matches = append(matches, second)
+		}
+	}
+
+	if fmt.Sprintf("%v", matches) != "[0 15 30 45]" {
+		t.Errorf("Incorrect matching seconds for seconds step pattern: %v", matches)
+	}
+}
+
+func TestJobFields(t *testing.T) {
+	t.Parallel()
+
+	minute, hour, dayOfMonth, month, dayOfWeek := Job{Pattern: "*/5 9-17 1 JAN FRI"}.Fields()
+	result := fmt.Sprintf("%s %s %s %s %s", minute, hour, dayOfMonth, month, dayOfWeek)
+	if result != "*/5 9-17 1 1 5" {
+		t.Errorf("Incorrect fields for named pattern: '%s'", result)
+	}
+
+	minute, _, _, _, _ = Job{Pattern: "foo"}.Fields()
+	if minute != "" {
+		t.Errorf("Unexpected fields for invalid pattern")
+	}
+}
+
+func TestJobMultiplePatterns(t *testing.T) {
+	t.Parallel()
+
+	// 9AM on weekdays, 11AM on weekends
+	job := Job{Patterns: []string{"0 9 * * 1-5", "0 11 * * 0,6"}}
+	if err := job.Validate(); err != nil {
+		t.Fatalf("Unexpected validation error: %s", err.Error())
+	}
+
+	expect := func(expected bool, clock time.Time) {
+		if result := job.matchAt(clock); result != expected {
+			t.Errorf("Incorrect run result for multiple patterns at time '%s'. Got %v expected %v", clock, result, expected)
+		}
+	}
+
+	expect(true, time.Date(2021, time.January, 4, 9, 0, 0, 0, time.UTC))   // Monday
+	expect(false, time.Date(2021, time.January, 4, 11, 0, 0, 0, time.UTC)) // Monday
+	expect(true, time.Date(2021, time.January, 2, 11, 0, 0, 0, time.UTC))  // Saturday
+	expect(false, time.Date(2021, time.January, 2, 9, 0, 0, 0, time.UTC))  // Saturday
+
+	next, ok := job.NextRun(time.Date(2021, time.January, 1, 10, 0, 0, 0, time.UTC)) // Friday
+	if !ok || !next.Equal(time.Date(2021, time.January, 2, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("Incorrect next run for multiple patterns: %s", next)
+	}
+
+	if err := (Job{Patterns: []string{"0 9 * * *", "foo"}}).Validate(); err == nil {
+		t.Errorf("No error seen for invalid pattern in patterns")
+	}
+}
+
+func TestWeekdayRangeWrapAround(t *testing.T) {
+	t.Parallel()
+
+	job := Job{Pattern: "* * * * FRI-MON"}
+	if err := job.Validate(); err != nil {
+		t.Fatalf("Unexpected validation error: %s", err.Error())
+	}
+
+	// January 3rd 2021 is a Sunday
+	expected := map[time.Weekday]bool{
+		time.Sunday:    true,
+		time.Monday:    true,
+		time.Tuesday:   false,
+		time.Wednesday: false,
+		time.Thursday:  false,
+		time.Friday:    true,
+		time.Saturday:  true,
+	}
+	for i := 0; i < 7; i++ {
+		clock := time.Date(2021, time.January, 3+i, 12, 0, 0, 0, time.UTC)
+		if result := job.matchAt(clock); result != expected[clock.Weekday()] {
+			t.Errorf("Incorrect run result for pattern '%s' on %s. Got %v expected %v", job.Pattern, clock.Weekday(), result, expected[clock.Weekday()])
+		}
+	}
+
+	if result := patternDoesMatch(getRealPattern("* * * * 5-1"), time.Date(2021, time.January, 3, 12, 0, 0, 0, time.UTC)); !result {
+		t.Errorf("Numeric wrap around range did not match Sunday")
+	}
+}
+
+func TestJobWouldRunNowInvalid(t *testing.T) {
+	t.Parallel()
+
+	// Jobs created without New are not validated first
+	for _, pattern := range []string{"* * *", "", "foo", "* * * * * *", "0 25 * * *"} {
+		job := Job{Pattern: pattern}
+		if job.WouldRunNow() {
+			t.Errorf("Invalid pattern '%s' should never run", pattern)
+		}
+		if job.WouldRunNowInTZ(time.UTC) {
+			t.Errorf("Invalid pattern '%s' should never run", pattern)
+		}
+	}
+}
+
+func TestPatternNearestWeekday(t *testing.T) {
+	t.Parallel()
+
+	expect := func(expected bool, pattern string, year int, month time.Month, day int) {
+		clock := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		result := patternDoesMatch(getRealPattern(pattern), clock)
+		if result != expected {
+			t.Errorf("Incorrect run now result for pattern '%s' at time '%s'. Got %v expected %v", pattern, clock, result, expected)
+		}
+	}
+
+	// The 15th is a weekday
+	expect(true, "0 0 15W * *", 2021, time.June, 15)
+	expect(false, "0 0 15W * *", 2021, time.June, 14)
+
+	// The 15th is a Saturday, run on Friday the 14th
+	expect(true, "0 0 15W * *", 2021, time.May, 14)
+	expect(false, "0 0 15W * *", 2021, time.May, 15)
+
+	// The 15th is a Sunday, run on Monday the 16th
+	expect(true, "0 0 15W * *", 2021, time.August, 16)
+	expect(false, "0 0 15W * *", 2021, time.August, 15)
+
+	// The 1st is a Saturday, run on Monday the 3rd rather than the previous month
+	expect(true, "0 0 1W * *", 2022, time.January, 3)
+	expect(false, "0 0 1W * *", 2021, time.December, 31)
+
+	// The 31st doesn't exist in June
+	expect(false, "0 0 31W * *", 2021, time.June, 30)
+
+	// The last day is a Sunday, run on Friday the 29th
+	expect(true, "0 0 LW * *", 2021, time.October, 29)
+	expect(false, "0 0 LW * *", 2021, time.October, 31)
+	// The last day is a Saturday, run on Friday the 30th
+	expect(true, "0 0 LW * *", 2021, time.July, 30)
+	// The last day of February is a Sunday, run on Friday the 26th
+	expect(true, "0 0 lw * *", 2021, time.February, 26)
+	// The last day is a weekday
+	expect(true, "0 0 LW * *", 2021, time.June, 30)
+
+	job := Job{Pattern: "0 0 15W * *"}
+	next, _ := job.NextRun(time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC))
+	if !next.Equal(time.Date(2021, time.May, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected next run %s", next)
+	}
+}
+
+func TestPatternNamedLists(t *testing.T) {
+	t.Parallel()
+
+	expect := func(expected bool, pattern string, clock time.Time) {
+		result := patternDoesMatch(getRealPattern(pattern), clock)
+		if result != expected {
+			t.Errorf("Incorrect run now result for pattern '%s' at time '%s'. Got %v expected %v", pattern, clock, result, expected)
+		}
+	}
+
+	expect(true, "0 0 1 JAN,JUL *", time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC))
+	expect(true, "0 0 1 JAN,JUL *", time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC))
+	expect(false, "0 0 1 JAN,JUL *", time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC))
+
+	// January 4th 2021 is a Monday
+	expect(true, "0 0 * * MON,WED,FRI", time.Date(2021, time.January, 4, 0, 0, 0, 0, time.UTC))
+	expect(false, "0 0 * * MON,WED,FRI", time.Date(2021, time.January, 5, 0, 0, 0, 0, time.UTC))
+	expect(true, "0 0 * * MON,WED,FRI", time.Date(2021, time.January, 6, 0, 0, 0, 0, time.UTC))
+	expect(true, "0 0 * * mon,wed,fri", time.Date(2021, time.January, 8, 0, 0, 0, 0, time.UTC))
+}
+
+func TestPatternFullRanges(t *testing.T) {
+	t.Parallel()
+
+	matchesAll := func(pattern string, component int, max int) {
+		if err := (Job{Pattern: pattern}).Validate(); err != nil {
+			t.Fatalf("Unexpected error validating pattern '%s': %s", pattern, err.Error())
+		}
+		parsed := getRealPattern(pattern)
+		for v := 0; v <= max+1; v++ {
+			expected := v <= max
+			if result := isItTime(parsed[component], v); result != expected {
+				t.Errorf("Incorrect match for pattern '%s' with value %d. Got %v expected %v", pattern, v, result, expected)
+			}
+		}
+	}
+
+	matchesAll("0-59 * * * *", 0, 59)
+	matchesAll("0-59/1 * * * *", 0, 59)
+	matchesAll("0 0-23 * * *", 1, 23)
+	matchesAll("0 0-23/1 * * *", 1, 23)
+	matchesAll("0 0 * * 0-6", 4, 6)
+
+	for _, pattern := range []string{"0-60 * * * *", "0 0-24 * * *", "0 0 0-31 * *", "0 0 * 0-12 *", "0 0 * * 0-7"} {
+		if err := (Job{Pattern: pattern}).Validate(); err == nil {
+			t.Errorf("No error seen for out of bounds pattern '%s'", pattern)
+		}
+	}
+
+	// A range of a single value only matches that value
+	if err := (Job{Pattern: "5-5 * * * *"}).Validate(); err != nil {
+		t.Errorf("Unexpected error validating single-value range: %s", err.Error())
+	}
+	for v := 0; v <= 59; v++ {
+		if result := isItTime("5-5", v); result != (v == 5) {
+			t.Errorf("Incorrect match for single-value range with value %d. Got %v", v, result)
+		}
+	}
+
+	// Every minute of a day matches exactly once
+	job := Job{Pattern: "0-59 0-23 * * *"}
+	start := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if runs := job.RunsBetween(start, start.Add(24*time.Hour-time.Minute)); len(runs) != 24*60 {
+		t.Errorf("Unexpected number of runs in a day. Expected %d got %d", 24*60, len(runs))
+	}
+}
+
+func TestPatternLeadingZeros(t *testing.T) {
+	t.Parallel()
+
+	expect := func(expected bool, pattern string, clock time.Time) {
+		result := patternDoesMatch(getRealPattern(pattern), clock)
+		if result != expected {
+			t.Errorf("Incorrect run now result for pattern '%s' at time '%s'. Got %v expected %v", pattern, clock, result, expected)
+		}
+	}
+
+	expect(true, "05 09 * * *", time.Date(2021, time.January, 1, 9, 5, 0, 0, time.UTC))
+	expect(true, "00 00 01 01 *", time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC))
+	expect(true, "0 08,09 * * *", time.Date(2021, time.January, 1, 9, 0, 0, 0, time.UTC))
+	expect(false, "05 09 * * *", time.Date(2021, time.January, 1, 9, 50, 0, 0, time.UTC))
+}
+
+func TestPatternStepFromValue(t *testing.T) {
+	t.Parallel()
+
+	job := Job{Pattern: "7/30 * * * *"}
+	if err := job.Validate(); err != nil {
+		t.Fatalf("Unexpected error validating pattern: %s", err.Error())
+	}
+
+	minutes := []int{}
+	hour := time.Date(2021, time.January, 1, 9, 0, 0, 0, time.UTC)
+	for minute := 0; minute < 60; minute++ {
+		if job.WouldRunNow(WithTime(hour.Add(time.Duration(minute) * time.Minute))) {
+			minutes = append(minutes, minute)
+		}
+	}
+	if fmt.Sprintf("%v", minutes) != "[7 37]" {
+		t.Errorf("Unexpected matching minutes for '%s': %v", job.Pattern, minutes)
+	}
+
+	runs := job.RunsBetween(hour, hour.Add(time.Hour))
+	if len(runs) != 2 || runs[0].Minute() != 7 || runs[1].Minute() != 37 {
+		t.Errorf("Unexpected runs for '%s': %v", job.Pattern, runs)
+	}
+
+	// Steps from a named value run until the end of the component
+	weekdays := Job{Pattern: "0 0 * * MON/2"}
+	if err := weekdays.Validate(); err != nil {
+		t.Fatalf("Unexpected error validating pattern: %s", err.Error())
+	}
+	for day, expected := range []bool{false, true, false, true, false, true, false} {
+		if actual := isItTime("1/2", day); actual != expected {
+			t.Errorf("Unexpected result for day of week %d. Expected %v got %v", day, expected, actual)
+		}
+	}
+}
+
+func TestPatternsOverlap(t *testing.T) {
+	t.Parallel()
+
+	after := time.Date(2021, time.January, 1, 0, 30, 0, 0, time.UTC)
+	expect := func(a, b string, expected bool, expectedAt time.Time) {
+		overlap, at := patternsOverlapAfter(a, b, after)
+		if overlap != expected {
+			t.Errorf("Incorrect overlap for patterns '%s' and '%s'. Expected %v got %v", a, b, expected, overlap)
+			return
+		}
+		if !at.Equal(expectedAt) {
+			t.Errorf("Incorrect overlap time for patterns '%s' and '%s'. Expected %s got %s", a, b, expectedAt, at)
+		}
+	}
+
+	expect("0 * * * *", "* * * * *", true, time.Date(2021, time.January, 1, 1, 0, 0, 0, time.UTC))
+	expect("* * * * *", "0 * * * *", true, time.Date(2021, time.January, 1, 1, 0, 0, 0, time.UTC))
+	expect("0 2 * * *", "0 3 * * *", false, time.Time{})
+	expect("*/15 * * * *", "*/20 * * * *", true, time.Date(2021, time.January, 1, 1, 0, 0, 0, time.UTC))
+	// The first Friday the 13th in 2021 is in August
+	expect("0 0 13 * *", "0 0 * * FRI", true, time.Date(2021, time.August, 13, 0, 0, 0, 0, time.UTC))
+	expect("0 0 13 * *", "0 0 1 * *", false, time.Time{})
+	expect("0 0 * * *", "0 0 31 2 *", false, time.Time{})
+	expect("0 0 * * *", "invalid", false, time.Time{})
+
+	if overlap, at := PatternsOverlap("0 * * * *", "* * * * *"); !overlap || at.Minute() != 0 || !at.After(time.Now()) {
+		t.Errorf("Unexpected overlap from now: %v %s", overlap, at)
+	}
+}
+
+func TestPatternMeridiem(t *testing.T) {
+	t.Parallel()
+
+	expect := func(pattern string, expected string) {
+		job := Job{Pattern: pattern}
+		if err := job.Validate(); err != nil {
+			t.Errorf("Unexpected error validating pattern '%s': %s", pattern, err.Error())
+			return
+		}
+		if actual := getRealPattern(pattern)[1]; actual != expected {
+			t.Errorf("Unexpected hour for '%s'. Expected '%s' got '%s'", pattern, expected, actual)
+		}
+	}
+
+	expect("0 12AM * * *", "0")
+	expect("0 1AM * * *", "1")
+	expect("0 11AM * * *", "11")
+	expect("0 12PM * * *", "12")
+	expect("0 1pm * * *", "13")
+	expect("0 11PM * * *", "23")
+	expect("0 9AM-5PM * * *", "9-17")
+	expect("0 11AM-1PM * * *", "11-13")
+	expect("0 9am,12pm,5pm * * *", "9,12,17")
+	expect("0 9AM-5PM/2 * * *", "9-17/2")
+
+	hours := []int{}
+	day := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	job := Job{Pattern: "0 11AM-1PM * * *"}
+	for hour := 0; hour < 24; hour++ {
+		if job.WouldRunNow(WithTime(day.Add(time.Duration(hour) * time.Hour))) {
+			hours = append(hours, hour)
+		}
+	}
+	if fmt.Sprintf("%v", hours) != "[11 12 13]" {
+		t.Errorf("Unexpected matching hours for '%s': %v", job.Pattern, hours)
+	}
+
+	for _, pattern := range []string{"0 0AM * * *", "0 13PM * * *", "0 9XM * * *", "9AM * * * *", "0 0 1 9AM *"} {
+		if err := (Job{Pattern: pattern}).Validate(); err == nil {
+			t.Errorf("No error seen for invalid pattern '%s'", pattern)
+		}
+	}
+}
+
+func TestMatchParsed(t *testing.T) {
+	t.Parallel()
+
+	job := Job{Pattern: "0 9-17 * JAN MON-FRI"}
+	minute, hour, dayOfMonth, month, dayOfWeek := job.Fields()
+	parsed := []string{minute, hour, dayOfMonth, month, dayOfWeek}
+
+	for _, clock := range []time.Time{
+		time.Date(2021, time.January, 4, 9, 0, 0, 0, time.UTC),
+		time.Date(2021, time.January, 4, 9, 30, 0, 0, time.UTC),
+		time.Date(2021, time.January, 9, 12, 0, 0, 0, time.UTC),
+		time.Date(2021, time.February, 1, 12, 0, 0, 0, time.UTC),
+	} {
+		expected := job.WouldRunNow(WithTime(clock))
+		if actual := MatchParsed(parsed, clock); actual != expected {
+			t.Errorf("Unexpected match for %s. Expected %v got %v", clock, expected, actual)
+		}
+	}
+	if !MatchParsed(parsed, time.Date(2021, time.January, 4, 17, 0, 0, 0, time.UTC)) {
+		t.Errorf("Parsed pattern did not match")
+	}
+	if MatchParsed(parsed[:4], time.Date(2021, time.January, 4, 17, 0, 0, 0, time.UTC)) {
+		t.Errorf("Incomplete parsed pattern matched")
+	}
+
+	// A bare wildcard in a list matches every value
+	if !MatchParsed([]string{"*,5", "*", "*", "*", "*"}, time.Date(2021, time.January, 4, 17, 3, 0, 0, time.UTC)) {
+		t.Errorf("Wildcard list element did not match")
+	}
+	// Day of week ranges may wrap around, and the weekday nearest a day of the month is permitted
+	if !MatchParsed([]string{"0", "0", "4W", "*", "5-1"}, time.Date(2021, time.January, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Parsed pattern with a wrapping range and nearest weekday did not match")
+	}
+	for _, malformed := range [][]string{
+		{"*/0", "*", "*", "*", "*"},
+		{"5/0", "*", "*", "*", "*"},
+		{"1-5/0", "*", "*", "*", "*"},
+		{"*/-1", "*", "*", "*", "*"},
+		{"0", "0", "LW,", "*", "*"},
+		{"0", "0", "W", "*", "*"},
+		{"", "", "", "", ""},
+		{"60", "*", "*", "*", "*"},
+		{"0", "1-2-3", "*", "*", "*"},
+		{"0", "17-9", "*", "*", "*"},
+		{"0", "0", "32W", "*", "*"},
+		{"0", "0", "*", "JAN", "*"},
+		{"0", "0", "*", "*", "-1"},
+	} {
+		if MatchParsed(malformed, time.Date(2021, time.January, 4, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("Malformed parsed pattern %v matched", malformed)
+		}
+	}
+}
+
+func TestPatternNicknames(t *testing.T) {
+	t.Parallel()
+
+	expect := func(pattern string, expected []bool) {
+		job := Job{Pattern: pattern}
+		if err := job.Validate(); err != nil {
+			t.Errorf("Unexpected error validating pattern '%s': %s", pattern, err.Error())
+			return
+		}
+		// January 3rd 2021 is a Sunday
+		for i, expectedMatch := range expected {
+			clock := time.Date(2021, time.January, 3+i, 12, 30, 0, 0, time.UTC)
+			if actual := job.WouldRunNow(WithTime(clock)); actual != expectedMatch {
+				t.Errorf("Unexpected match for '%s' on %s. Expected %v got %v", pattern, clock.Weekday(), expectedMatch, actual)
+			}
+		}
+	}
+
+	expect("@weekdays", []bool{false, true, true, true, true, true, false})
+	expect("@weekends", []bool{true, false, false, false, false, false, true})
+	expect("@WEEKDAYS", []bool{false, true, true, true, true, true, false})
+
+	if normalized, err := Normalize("@weekends"); err != nil || normalized != "* * * * 0,6" {
+		t.Errorf("Unexpected normalized pattern '%s': %v", normalized, err)
+	}
+	if err := (Job{Pattern: "@weekday"}).Validate(); err == nil {
+		t.Errorf("No error seen for unknown nickname")
+	}
+}
+
+func TestPatternMixedNamedLists(t *testing.T) {
+	t.Parallel()
+
+	// January 3rd 2021 is a Sunday
+	expectWeekdays := func(pattern string, expected string) {
+		job := Job{Pattern: pattern}
+		if err := job.Validate(); err != nil {
+			t.Errorf("Unexpected error validating pattern '%s': %s", pattern, err.Error())
+			return
+		}
+		days := []time.Weekday{}
+		for i := 0; i < 7; i++ {
+			clock := time.Date(2021, time.January, 3+i, 9, 0, 0, 0, time.UTC)
+			if job.WouldRunNow(WithTime(clock)) {
+				days = append(days, clock.Weekday())
+			}
+		}
+		if actual := fmt.Sprintf("%v", days); actual != expected {
+			t.Errorf("Unexpected matching days for '%s'. Expected %s got %s", pattern, expected, actual)
+		}
+	}
+
+	expectWeekdays("0 9 * * MON-WED,FRI", "[Monday Tuesday Wednesday Friday]")
+	expectWeekdays("0 9 * * mon-wed,5", "[Monday Tuesday Wednesday Friday]")
+	expectWeekdays("0 9 * * 1-3,FRI", "[Monday Tuesday Wednesday Friday]")
+	expectWeekdays("0 9 * * MON-FRI/2,SUN", "[Sunday Monday Wednesday Friday]")
+	expectWeekdays("0 9 * * FRI-MON,WED", "[Sunday Monday Wednesday Friday Saturday]")
+	expectWeekdays("0 9 * * Monday-Wednesday,Friday", "[Monday Tuesday Wednesday Friday]")
+
+	expectMonths := func(pattern string, expected string) {
+		job := Job{Pattern: pattern}
+		if err := job.Validate(); err != nil {
+			t.Errorf("Unexpected error validating pattern '%s': %s", pattern, err.Error())
+			return
+		}
+		months := []time.Month{}
+		for month := time.January; month <= time.December; month++ {
+			if job.WouldRunNow(WithTime(time.Date(2021, month, 1, 9, 0, 0, 0, time.UTC))) {
+				months = append(months, month)
+			}
+		}
+		if actual := fmt.Sprintf("%v", months); actual != expected {
+			t.Errorf("Unexpected matching months for '%s'. Expected %s got %s", pattern, expected, actual)
+		}
+	}
+
+	expectMonths("0 9 1 JAN-MAR,JUN *", "[January February March June]")
+	expectMonths("0 9 1 SEP-DEC/2,2 *", "[February September November]")
+	expectMonths("0 9 1 1-2,JUL,oct-nov *", "[January February July October November]")
+
+	// Thursday is excluded from the next runs
+	job := Job{Pattern: "0 9 * * MON-WED,FRI"}
+	next, ok := job.NextRun(time.Date(2021, time.January, 6, 9, 0, 0, 0, time.UTC))
+	if !ok || next.Weekday() != time.Friday {
+		t.Errorf("Unexpected next run %s", next)
+	}
+	if normalized, err := Normalize(job.Pattern); err != nil || normalized != "0 9 * * 1-3,5" {
+		t.Errorf("Unexpected normalized pattern '%s': %v", normalized, err)
+	}
+
+	for _, pattern := range []string{"0 9 * * MON-XYZ,FRI", "0 9 * * MON-WED,", "0 9 * MON-WED,FRI *", "0 9 * JAN-FEB,SUN *"} {
+		if err := (Job{Pattern: pattern}).Validate(); err == nil {
+			t.Errorf("No error seen for invalid pattern '%s'", pattern)
+		}
+	}
+}
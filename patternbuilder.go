@@ -0,0 +1,88 @@
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PatternBuilder provides a fluent way to describe a pattern, such as:
+//
+//	cron.Pattern().Minutes(0).Hours(9, 17).Weekdays(time.Monday, time.Friday).Build()
+//
+// Any component that isn't set is a wildcard. Values are not checked until Build is called.
+type PatternBuilder struct {
+	components [5]string
+}
+
+// Pattern returns a new pattern builder where every component is a wildcard
+func Pattern() *PatternBuilder {
+	return &PatternBuilder{
+		components: [5]string{"*", "*", "*", "*", "*"},
+	}
+}
+
+// Minutes sets the minutes (0-59) that the pattern matches
+func (b *PatternBuilder) Minutes(minutes ...int) *PatternBuilder {
+	b.components[0] = joinValues(minutes)
+	return b
+}
+
+// EveryMinutes sets the pattern to match every n minutes, starting from the start of the hour
+func (b *PatternBuilder) EveryMinutes(n int) *PatternBuilder {
+	b.components[0] = "*/" + strconv.Itoa(n)
+	return b
+}
+
+// Hours sets the hours (0-23) that the pattern matches
+func (b *PatternBuilder) Hours(hours ...int) *PatternBuilder {
+	b.components[1] = joinValues(hours)
+	return b
+}
+
+// EveryHours sets the pattern to match every n hours, starting from midnight
+func (b *PatternBuilder) EveryHours(n int) *PatternBuilder {
+	b.components[1] = "*/" + strconv.Itoa(n)
+	return b
+}
+
+// DaysOfMonth sets the days of the month (1-31) that the pattern matches
+func (b *PatternBuilder) DaysOfMonth(days ...int) *PatternBuilder {
+	b.components[2] = joinValues(days)
+	return b
+}
+
+// Months sets the months that the pattern matches
+func (b *PatternBuilder) Months(months ...time.Month) *PatternBuilder {
+	values := make([]int, len(months))
+	for i, month := range months {
+		values[i] = int(month)
+	}
+	b.components[3] = joinValues(values)
+	return b
+}
+
+// Weekdays sets the days of the week that the pattern matches
+func (b *PatternBuilder) Weekdays(weekdays ...time.Weekday) *PatternBuilder {
+	values := make([]int, len(weekdays))
+	for i, weekday := range weekdays {
+		values[i] = int(weekday)
+	}
+	b.components[4] = joinValues(values)
+	return b
+}
+
+// Build returns the normalized pattern, or an error if any of the values are not valid
+func (b *PatternBuilder) Build() (string, error) {
+	return Normalize(strings.Join(b.components[:], " "))
+}
+
+// joinValues returns a comma-separated list of the values, or an empty string (which will fail validation) if there are
+// no values
+func joinValues(values []int) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = strconv.Itoa(value)
+	}
+	return strings.Join(parts, ",")
+}
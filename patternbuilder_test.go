@@ -0,0 +1,59 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestPatternBuilder(t *testing.T) {
+	t.Parallel()
+
+	expect := func(builder *cron.PatternBuilder, expected string) {
+		pattern, err := builder.Build()
+		if err != nil {
+			t.Errorf("Unexpected error building pattern '%s': %s", expected, err.Error())
+			return
+		}
+		if pattern != expected {
+			t.Errorf("Unexpected pattern. Expected '%s' got '%s'", expected, pattern)
+		}
+		if err := (cron.Job{Pattern: pattern}).Validate(); err != nil {
+			t.Errorf("Built pattern '%s' is not valid: %s", pattern, err.Error())
+		}
+	}
+
+	expect(cron.Pattern(), "* * * * *")
+	expect(cron.Pattern().Minutes(0).Hours(9, 17).Weekdays(time.Monday, time.Friday), "0 9,17 * * 1,5")
+	expect(cron.Pattern().Minutes(30).Hours(2), "30 2 * * *")
+	expect(cron.Pattern().EveryMinutes(5), "*/5 * * * *")
+	expect(cron.Pattern().Minutes(0).EveryHours(2), "0 */2 * * *")
+	expect(cron.Pattern().Minutes(0).Hours(0).DaysOfMonth(1).Months(time.January, time.July), "0 0 1 1,7 *")
+	// Values are sorted and deduplicated
+	expect(cron.Pattern().Minutes(45, 15, 15), "15,45 * * * *")
+
+	// The built pattern is the same as the normalized hand-written pattern
+	built, _ := cron.Pattern().Minutes(0).Hours(9).Weekdays(time.Monday, time.Tuesday, time.Wednesday).Build()
+	written, _ := cron.Normalize("0 9 * * MON,TUE,WED")
+	if built != written {
+		t.Errorf("Built pattern '%s' does not match hand-written pattern '%s'", built, written)
+	}
+}
+
+func TestPatternBuilderInvalid(t *testing.T) {
+	t.Parallel()
+
+	expect := func(builder *cron.PatternBuilder) {
+		if pattern, err := builder.Build(); err == nil {
+			t.Errorf("No error seen for invalid pattern '%s'", pattern)
+		}
+	}
+
+	expect(cron.Pattern().Minutes(60))
+	expect(cron.Pattern().Hours(24))
+	expect(cron.Pattern().Hours())
+	expect(cron.Pattern().DaysOfMonth(0))
+	expect(cron.Pattern().EveryMinutes(0))
+	expect(cron.Pattern().Weekdays(time.Weekday(7)))
+}
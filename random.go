@@ -0,0 +1,49 @@
+package cron
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+// randomComponent is a component that resolves to a random value when the pattern is parsed
+const randomComponent = "~"
+
+// randomSalt is chosen once per process and mixed into the random values of jobs that aren't seeded, so that the
+// values differ between processes but stay the same for as long as the process runs
+var randomSalt = uint32(time.Now().UnixNano())
+
+// resolveRandom returns a random value within the bounds of the component if the component is ~, otherwise the
+// component is returned as-is. If random is nil then the value is chosen from the key, which identifies the job and
+// pattern, so that the same key always resolves to the same value within the process.
+func resolveRandom(component string, i int, random *rand.Rand, key string) string {
+	if component != randomComponent {
+		return component
+	}
+
+	low, high := hashBounds(i)
+	var v int
+	if random == nil {
+		h := fnv.New32a()
+		binary.Write(h, binary.LittleEndian, randomSalt)
+		h.Write([]byte(key))
+		h.Write([]byte{byte(i)})
+		v = int(h.Sum32() % uint32(high-low+1))
+	} else {
+		v = random.Intn(high - low + 1)
+	}
+	return strconv.Itoa(low + v)
+}
+
+// SetSeed chooses new values for any random ~ components of the jobs in this tab using the given seed, so that the
+// same seed always results in the same values. This must be called before the tab is started, and again if any jobs
+// are changed.
+func (s *Tab) SetSeed(seed int64) {
+	s.seed = &seed
+	random := rand.New(rand.NewSource(seed))
+	for i, job := range s.Jobs {
+		s.Jobs[i].parsed = job.parseWith(random)
+	}
+}
@@ -0,0 +1,121 @@
+package cron_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestRandomComponent(t *testing.T) {
+	t.Parallel()
+
+	seededMinute := func(seed int64) (string, *cron.Tab) {
+		tab, err := cron.New([]cron.Job{
+			{
+				Name:    "Spread",
+				Pattern: "~ */2 * * *",
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+		tab.SetSeed(seed)
+		minute, _, _, _, _ := tab.Jobs[0].Fields()
+		return minute, tab
+	}
+
+	first, tab := seededMinute(42)
+	second, _ := seededMinute(42)
+	if first != second {
+		t.Errorf("Seeded random minute is not deterministic. Got '%s' and '%s'", first, second)
+	}
+	v, err := strconv.Atoi(first)
+	if err != nil || v < 0 || v > 59 {
+		t.Errorf("Random minute did not resolve to a valid value: '%s'", first)
+	}
+	if again, _, _, _, _ := tab.Jobs[0].Fields(); again != first {
+		t.Errorf("Random minute changed after being chosen. Expected '%s' got '%s'", first, again)
+	}
+	if cloned, _, _, _, _ := tab.Clone().Jobs[0].Fields(); cloned != first {
+		t.Errorf("Random minute changed in clone. Expected '%s' got '%s'", first, cloned)
+	}
+
+	differs := false
+	for seed := int64(0); seed < 10; seed++ {
+		if minute, _ := seededMinute(seed); minute != first {
+			differs = true
+		}
+	}
+	if !differs {
+		t.Errorf("Random minute is the same for every seed")
+	}
+
+	job := cron.Job{Pattern: "0 0 ~ * *"}
+	_, _, d, _, _ := job.Fields()
+	dayOfMonth, _ := strconv.Atoi(d)
+	if dayOfMonth < 1 || dayOfMonth > 28 {
+		t.Errorf("Random day of month outside of range: %d", dayOfMonth)
+	}
+}
+
+func TestValidateRandomComponent(t *testing.T) {
+	t.Parallel()
+
+	expect := func(e bool, p string) {
+		r := cron.Job{Pattern: p}.Validate()
+		if (r == nil) != e {
+			t.Errorf("Incorrect validation result for pattern '%s'. Error: %v", p, r)
+		}
+	}
+
+	expect(true, "~ * * * *")
+	expect(true, "~ ~ ~ ~ ~")
+	expect(false, "~5 * * * *")
+	expect(false, "~,5 * * * *")
+	expect(false, "~/5 * * * *")
+}
+
+func TestRandomComponentStable(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:    "Unseeded",
+			Pattern: "~ * * * *",
+		},
+	})
+	minute, _, _, _, _ := tab.Jobs[0].Fields()
+	clone := tab.Clone()
+	if cloned, _, _, _, _ := clone.Jobs[0].Fields(); cloned != minute {
+		t.Errorf("Random minute changed in clone. Expected '%s' got '%s'", minute, cloned)
+	}
+	if !tab.Equal(clone) {
+		t.Errorf("Clone is not equal to the original tab")
+	}
+
+	// Jobs added after the tab was created and jobs that were never in a tab keep the same value
+	tab.Jobs = append(tab.Jobs, cron.Job{Name: "Added", Pattern: "~ * * * *"})
+	day := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if runs := tab.Jobs[1].RunsBetween(day, day.Add(24*time.Hour-time.Minute)); len(runs) != 24 {
+		t.Errorf("Unexpected number of runs in a day for a job added after creating the tab. Expected %d got %d", 24, len(runs))
+	}
+	matches := 0
+	for m := 0; m < 24*60; m++ {
+		if tab.Jobs[1].WouldRunNow(cron.WithTime(day.Add(time.Duration(m) * time.Minute))) {
+			matches++
+		}
+	}
+	if matches != 24 {
+		t.Errorf("Unexpected number of matches in a day for a job added after creating the tab. Expected %d got %d", 24, matches)
+	}
+
+	job := cron.Job{Name: "Standalone", Pattern: "~ 9 * * *"}
+	first, _ := job.NextRun(day)
+	for i := 0; i < 10; i++ {
+		if next, _ := job.NextRun(day); !next.Equal(first) {
+			t.Errorf("Next run changed between calls. Expected %s got %s", first, next)
+		}
+	}
+}
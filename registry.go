@@ -0,0 +1,43 @@
+package cron
+
+import (
+	"fmt"
+	"sync"
+)
+
+var registryLock sync.RWMutex
+var registry = map[string]func(){}
+
+// Register adds the method to the global registry with the given name, replacing any method already registered with
+// that name. Registered methods can be used by jobs created with NewFromRegistry. Safe to call from multiple
+// goroutines.
+func Register(name string, exec func()) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	registry[name] = exec
+}
+
+// Lookup returns the method registered with the given name, if any
+func Lookup(name string) (func(), bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	exec, ok := registry[name]
+	return exec, ok
+}
+
+// NewFromRegistry will create a new tab for the given jobs like New, but any job without an Exec, ExecAt, or ExecE
+// method will use the method registered with the name of the job. An error is returned if there is no method registered
+// for a job.
+func NewFromRegistry(Jobs []Job) (*Tab, error) {
+	for i, job := range Jobs {
+		if job.Exec != nil || job.ExecAt != nil || job.ExecE != nil {
+			continue
+		}
+		exec, ok := Lookup(job.Name)
+		if !ok {
+			return nil, fmt.Errorf("job '%s': no registered method", job.Name)
+		}
+		Jobs[i].Exec = exec
+	}
+	return New(Jobs)
+}
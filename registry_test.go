@@ -0,0 +1,66 @@
+package cron_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	var registered atomic.Int32
+	cron.Register("registry-test-job", func() {
+		registered.Add(1)
+	})
+
+	exec, ok := cron.Lookup("registry-test-job")
+	if !ok {
+		t.Fatalf("Registered method not found")
+	}
+	exec()
+	if registered.Load() != 1 {
+		t.Errorf("Looked up method is not the registered method")
+	}
+	if _, ok := cron.Lookup("registry-test-unknown"); ok {
+		t.Errorf("Unexpected method found for unknown name")
+	}
+
+	var explicit atomic.Int32
+	tab, err := cron.NewFromRegistry([]cron.Job{
+		{Name: "registry-test-job", Pattern: "* * * * *"},
+		{Name: "registry-test-explicit", Pattern: "* * * * *", Exec: func() { explicit.Add(1) }},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	tab.Jobs[0].Exec()
+	tab.Jobs[1].Exec()
+	if registered.Load() != 2 || explicit.Load() != 1 {
+		t.Errorf("Jobs did not resolve to the expected methods")
+	}
+
+	if _, err := cron.NewFromRegistry([]cron.Job{{Name: "registry-test-unknown", Pattern: "* * * * *"}}); err == nil {
+		t.Errorf("No error seen for job without a registered method")
+	}
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	t.Parallel()
+
+	done := make(chan struct{})
+	for i := 0; i < 10; i++ {
+		go func() {
+			cron.Register("registry-test-concurrent", func() {})
+			cron.Lookup("registry-test-concurrent")
+			done <- struct{}{}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		<-done
+	}
+	if _, ok := cron.Lookup("registry-test-concurrent"); !ok {
+		t.Errorf("Registered method not found")
+	}
+}
@@ -0,0 +1,58 @@
+package cron
+
+import (
+	"time"
+)
+
+// Simulate returns every time between from and until (inclusive) that the job would run if it were in a tab started at
+// from, in the same location as from. It is intended for tests that assert a job runs when expected. Unlike
+// RunsBetween, it includes jobs using Every, and considers the Holiday and RunLimit of the job. Returns nil if the job
+// is invalid.
+func Simulate(job Job, from time.Time, until time.Time) []time.Time {
+	if err := job.Validate(); err != nil {
+		return nil
+	}
+
+	var runs []time.Time
+	if every := job.every(); every > 0 {
+		runs = simulateEvery(job, every, from, until)
+	} else {
+		runs = job.RunsBetween(from, until)
+	}
+
+	simulated := []time.Time{}
+	for _, run := range runs {
+		if job.RunLimit > 0 && len(simulated) >= job.RunLimit {
+			break
+		}
+		if job.Holiday != nil && job.Holiday(run) {
+			continue
+		}
+		simulated = append(simulated, run)
+	}
+	return simulated
+}
+
+// simulateEvery returns every time between from and until that the job, which runs at a fixed interval, would run if
+// the tab was started at from
+func simulateEvery(job Job, every time.Duration, from time.Time, until time.Time) []time.Time {
+	// Without an anchor the first run is one interval after the tab starts
+	next := from.Add(every)
+	if job.EveryAnchor != nil {
+		since := from.Sub(*job.EveryAnchor)
+		intervals := since / every
+		if since > 0 && since%every != 0 {
+			intervals++
+		}
+		next = job.EveryAnchor.Add(intervals * every).In(from.Location())
+	}
+
+	runs := []time.Time{}
+	for ; !next.After(until); next = next.Add(every) {
+		if job.Window != nil && !job.Window.Contains(next) {
+			continue
+		}
+		runs = append(runs, next)
+	}
+	return runs
+}
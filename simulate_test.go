@@ -0,0 +1,58 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestSimulate(t *testing.T) {
+	t.Parallel()
+
+	expect := func(name string, runs []time.Time, expected ...time.Time) {
+		if len(runs) != len(expected) {
+			t.Errorf("Unexpected runs for %s. Expected %v got %v", name, expected, runs)
+			return
+		}
+		for i := range expected {
+			if !runs[i].Equal(expected[i]) {
+				t.Errorf("Unexpected run for %s. Expected %s got %s", name, expected[i], runs[i])
+			}
+		}
+	}
+
+	from := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	day := func(d int, hour int) time.Time {
+		return time.Date(2021, time.January, d, hour, 0, 0, 0, time.UTC)
+	}
+
+	// January 1st 2021 is a Friday
+	expect("pattern", cron.Simulate(cron.Job{Pattern: "0 9 * * MON-FRI"}, from, day(8, 9)),
+		day(1, 9), day(4, 9), day(5, 9), day(6, 9), day(7, 9), day(8, 9))
+
+	expect("holiday", cron.Simulate(cron.Job{
+		Pattern: "0 9 * * *",
+		Holiday: cron.Holidays(day(2, 0)),
+	}, from, day(3, 23)), day(1, 9), day(3, 9))
+
+	expect("run limit", cron.Simulate(cron.Job{
+		Pattern:  "0 9 * * *",
+		RunLimit: 2,
+		Holiday:  cron.Holidays(day(1, 0)),
+	}, from, day(10, 0)), day(2, 9), day(3, 9))
+
+	expect("every", cron.Simulate(cron.Job{Every: 6 * time.Hour}, from, day(2, 0)),
+		day(1, 6), day(1, 12), day(1, 18), day(2, 0))
+
+	anchor := time.Date(2020, time.December, 31, 1, 0, 0, 0, time.UTC)
+	expect("every anchor", cron.Simulate(cron.Job{Every: 6 * time.Hour, EveryAnchor: &anchor}, from, day(1, 12)),
+		day(1, 1), day(1, 7))
+
+	if runs := cron.Simulate(cron.Job{Pattern: "0 9 * *"}, from, day(2, 0)); runs != nil {
+		t.Errorf("Unexpected runs for invalid job %v", runs)
+	}
+	if runs := cron.Simulate(cron.Job{Pattern: "0 9 * * *"}, day(2, 0), from); runs == nil || len(runs) != 0 {
+		t.Errorf("Unexpected runs for empty window %v", runs)
+	}
+}
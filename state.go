@@ -0,0 +1,103 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// tabState is the persisted scheduling state of a tab
+type tabState struct {
+	TotalRuns uint64              `json:"total_runs"`
+	Jobs      map[string]jobSaved `json:"jobs"`
+}
+
+// jobSaved is the persisted scheduling state of a single job
+type jobSaved struct {
+	Runs       uint64     `json:"runs,omitempty"`
+	Panics     uint64     `json:"panics,omitempty"`
+	LastRun    *time.Time `json:"last_run,omitempty"`
+	Dispatched int        `json:"dispatched,omitempty"`
+	LastMatch  *time.Time `json:"last_match,omitempty"`
+	LastFire   *time.Time `json:"last_fire,omitempty"`
+}
+
+// SnapshotState returns the scheduling state of this tab encoded as JSON, including the statistics of each job and
+// what is needed to enforce run limits and avoid running a job twice for the same minute. Pass the snapshot to
+// RestoreState, such as after the process restarts, to continue where the tab left off. Jobs without a name are not
+// included.
+func (s *Tab) SnapshotState() []byte {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	state := tabState{
+		TotalRuns: s.totalRuns.Load(),
+		Jobs:      map[string]jobSaved{},
+	}
+	for i, job := range s.Jobs {
+		if job.Name == "" {
+			continue
+		}
+		jobState := s.jobState(i)
+		saved := jobSaved{
+			Runs:       s.jobRuns[job.Name],
+			Panics:     s.jobPanics[job.Name],
+			Dispatched: jobState.dispatched,
+			LastMatch:  timeOrNil(jobState.lastMatch),
+			LastFire:   timeOrNil(jobState.lastFire),
+		}
+		if lastRun, ok := s.lastRuns[job.Name]; ok {
+			saved.LastRun = &lastRun
+		}
+		state.Jobs[job.Name] = saved
+	}
+
+	data, _ := json.Marshal(state)
+	return data
+}
+
+// RestoreState replaces the scheduling state of this tab with a snapshot from SnapshotState. Jobs in the snapshot that
+// are not in this tab are ignored. An error is returned if the snapshot is malformed, in which case the state is not
+// changed.
+func (s *Tab) RestoreState(data []byte) error {
+	state := tabState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("invalid state: %s", err.Error())
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.totalRuns.Store(state.TotalRuns)
+	s.jobRuns = map[string]uint64{}
+	s.jobPanics = map[string]uint64{}
+	s.lastRuns = map[string]time.Time{}
+	s.jobStates = nil
+	for i, job := range s.Jobs {
+		saved, ok := state.Jobs[job.Name]
+		if job.Name == "" || !ok {
+			continue
+		}
+		s.jobRuns[job.Name] = saved.Runs
+		s.jobPanics[job.Name] = saved.Panics
+		if saved.LastRun != nil {
+			s.lastRuns[job.Name] = *saved.LastRun
+		}
+		jobState := s.jobState(i)
+		jobState.dispatched = saved.Dispatched
+		if saved.LastMatch != nil {
+			jobState.lastMatch = *saved.LastMatch
+		}
+		if saved.LastFire != nil {
+			jobState.lastFire = *saved.LastFire
+		}
+	}
+	return nil
+}
+
+// timeOrNil returns a pointer to the time, or nil if it is the zero time
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,86 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSnapshotState(t *testing.T) {
+	t.Parallel()
+
+	var limitedRuns atomic.Int32
+	var unlimitedRuns atomic.Int32
+	newTab := func() *Tab {
+		tab, _ := New([]Job{
+			{
+				Name:     "Limited",
+				Pattern:  "* * * * *",
+				RunLimit: 2,
+				Exec: func() {
+					limitedRuns.Add(1)
+				},
+			},
+			{
+				Name:    "Unlimited",
+				Pattern: "* * * * *",
+				Exec: func() {
+					unlimitedRuns.Add(1)
+				},
+			},
+		})
+		tab.TZ = time.UTC
+		tab.Sequential = true
+		return tab
+	}
+
+	tab := newTab()
+	start := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	clk, stop := startFakeTab(tab, start)
+	clk.tick(start.Add(time.Minute))
+	stop()
+
+	snapshot := tab.SnapshotState()
+	tab.ResetStats()
+	if tab.JobRuns("Limited") != 0 || tab.TotalRuns() != 0 {
+		t.Fatalf("Stats not reset")
+	}
+	if err := tab.RestoreState(snapshot); err != nil {
+		t.Fatalf("Unexpected error restoring state: %s", err.Error())
+	}
+	expectStats := func(tab *Tab) {
+		if runs := tab.JobRuns("Limited"); runs != 2 {
+			t.Errorf("Unexpected runs after restoring state. Expected %d got %d", 2, runs)
+		}
+		if total := tab.TotalRuns(); total != 4 {
+			t.Errorf("Unexpected total runs after restoring state. Expected %d got %d", 4, total)
+		}
+		if lastRun, ok := tab.LastRun("Unlimited"); !ok || !lastRun.Equal(start.Add(time.Minute)) {
+			t.Errorf("Unexpected last run after restoring state: %s", lastRun)
+		}
+	}
+	expectStats(tab)
+
+	// Restoring into a new tab, such as after a restart, keeps the run limit and doesn't repeat the last minute
+	restarted := newTab()
+	if err := restarted.RestoreState(snapshot); err != nil {
+		t.Fatalf("Unexpected error restoring state: %s", err.Error())
+	}
+	expectStats(restarted)
+	clk, stop = startFakeTab(restarted, start.Add(time.Minute))
+	clk.tick(start.Add(2 * time.Minute))
+	stop()
+	if runs := limitedRuns.Load(); runs != 2 {
+		t.Errorf("Limited job ran after reaching its limit. Expected %d runs got %d", 2, runs)
+	}
+	if runs := unlimitedRuns.Load(); runs != 3 {
+		t.Errorf("Unexpected runs of unlimited job. Expected %d got %d", 3, runs)
+	}
+
+	if err := restarted.RestoreState([]byte("not json")); err == nil {
+		t.Errorf("No error seen for malformed state")
+	}
+	if restarted.JobRuns("Unlimited") != 3 {
+		t.Errorf("State was changed by malformed snapshot")
+	}
+}
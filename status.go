@@ -0,0 +1,70 @@
+package cron
+
+import (
+	"sort"
+	"time"
+)
+
+// TabStatus describes a snapshot of the state of a tab, suitable for reporting from a health check
+type TabStatus struct {
+	// If the tab has been started and has not yet stopped
+	Running bool `json:"running"`
+	// The number of jobs in the tab
+	Jobs int `json:"jobs"`
+	// The next time any job using a pattern will run, not including jobs in a paused group or jobs that are skipped
+	// because they failed. Nil if no job will run.
+	NextRun *time.Time `json:"next_run,omitempty"`
+	// The last time each job ran, by job name. Jobs that have not run are not included.
+	LastRuns map[string]time.Time `json:"last_runs"`
+	// The number of job executions this tab has performed
+	TotalRuns uint64 `json:"total_runs"`
+	// When the tab will expire. Nil if the tab does not expire.
+	ExpireAfter *time.Time `json:"expire_after,omitempty"`
+	// The groups that are paused with PauseGroup, sorted by name
+	PausedGroups []string `json:"paused_groups"`
+	// The jobs using SkipAfterError that failed and are skipped until ClearError is called, sorted by name
+	FailedJobs []string `json:"failed_jobs"`
+}
+
+// Status returns a snapshot of the current state of the tab. The returned status is a copy and is safe to use while the
+// tab is running.
+func (s *Tab) Status() TabStatus {
+	now := s.getClock().Now().In(s.location())
+
+	status := TabStatus{
+		Jobs:      len(s.Jobs),
+		LastRuns:  map[string]time.Time{},
+		TotalRuns: s.TotalRuns(),
+	}
+	for _, job := range s.Jobs {
+		if s.groupPaused(job) || s.skippedForError(job) {
+			continue
+		}
+		next, ok := s.withWeekStart(job).NextRun(now)
+		if ok && (status.NextRun == nil || next.Before(*status.NextRun)) {
+			status.NextRun = &next
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	status.Running = s.running
+	status.PausedGroups = []string{}
+	for group := range s.pausedGroups {
+		status.PausedGroups = append(status.PausedGroups, group)
+	}
+	sort.Strings(status.PausedGroups)
+	status.FailedJobs = []string{}
+	for name := range s.failedJobs {
+		status.FailedJobs = append(status.FailedJobs, name)
+	}
+	sort.Strings(status.FailedJobs)
+	for name, lastRun := range s.lastRuns {
+		status.LastRuns[name] = lastRun
+	}
+	if s.ExpireAfter != nil {
+		expireAfter := *s.ExpireAfter
+		status.ExpireAfter = &expireAfter
+	}
+	return status
+}
@@ -0,0 +1,128 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestTabStatus(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:    "EveryMinute",
+			Pattern: "* * * * *",
+			Exec:    func() {},
+		},
+		{
+			Name:    "Yearly",
+			Pattern: "0 0 1 1 *",
+			Exec:    func() {},
+		},
+	})
+	expireAfter := time.Now().Add(24 * time.Hour)
+	tab.ExpireAfter = &expireAfter
+
+	status := tab.Status()
+	if status.Running || status.TotalRuns != 0 || len(status.LastRuns) != 0 {
+		t.Errorf("Unexpected status before starting %+v", status)
+	}
+	if status.Jobs != 2 {
+		t.Errorf("Unexpected number of jobs. Expected %d got %d", 2, status.Jobs)
+	}
+	if status.ExpireAfter == nil || !status.ExpireAfter.Equal(expireAfter) {
+		t.Errorf("Unexpected expiry %v", status.ExpireAfter)
+	}
+	if status.NextRun == nil || status.NextRun.Sub(time.Now()) > time.Minute {
+		t.Errorf("Unexpected next run %v", status.NextRun)
+	}
+
+	stop := tab.Run()
+	waitFor(t, "tab to start", func() bool { return tab.Status().Running })
+	waitFor(t, "job to run", func() bool { return tab.TotalRuns() > 0 })
+	stop()
+
+	status = tab.Status()
+	if status.Running {
+		t.Errorf("Tab should not be running after stop")
+	}
+	if status.TotalRuns != 1 {
+		t.Errorf("Unexpected total runs. Expected %d got %d", 1, status.TotalRuns)
+	}
+	if _, ok := status.LastRuns["EveryMinute"]; !ok {
+		t.Errorf("No last run for job that ran")
+	}
+	if _, ok := status.LastRuns["Yearly"]; ok {
+		t.Errorf("Unexpected last run for job that didn't run")
+	}
+}
+
+func TestTabStatusPausedGroups(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:    "Vacuum",
+			Group:   "maintenance",
+			Pattern: "* * * * *",
+			Exec:    func() {},
+		},
+		{
+			Name:    "Sales",
+			Group:   "reports",
+			Pattern: "0 0 1 1 *",
+			Exec:    func() {},
+		},
+	})
+	tab.TZ = time.UTC
+
+	if status := tab.Status(); len(status.PausedGroups) != 0 {
+		t.Errorf("Unexpected paused groups %v", status.PausedGroups)
+	}
+
+	tab.PauseGroup("reports")
+	tab.PauseGroup("maintenance")
+	status := tab.Status()
+	if len(status.PausedGroups) != 2 || status.PausedGroups[0] != "maintenance" || status.PausedGroups[1] != "reports" {
+		t.Errorf("Unexpected paused groups %v", status.PausedGroups)
+	}
+	if status.NextRun != nil {
+		t.Errorf("Unexpected next run when every group is paused %v", status.NextRun)
+	}
+
+	newYear := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if due := tab.DueAt(newYear); len(due) != 0 {
+		t.Errorf("Unexpected jobs due in paused groups %v", due)
+	}
+	tab.ResumeGroup("reports")
+	if due := tab.DueAt(newYear); len(due) != 1 || due[0].Name != "Sales" {
+		t.Errorf("Unexpected jobs due after resuming a group %v", due)
+	}
+	if status := tab.Status(); len(status.PausedGroups) != 1 || status.PausedGroups[0] != "maintenance" {
+		t.Errorf("Unexpected paused groups %v", status.PausedGroups)
+	}
+}
+
+func TestTabStatusWeekStart(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:    "FirstDayOfWeek",
+			Pattern: "0 12 * * 0",
+			Exec:    func() {},
+		},
+	})
+	tab.TZ = time.UTC
+	tab.WeekStart = time.Monday
+
+	status := tab.Status()
+	if status.NextRun == nil {
+		t.Fatalf("No next run")
+	}
+	if weekday := status.NextRun.In(time.UTC).Weekday(); weekday != time.Monday {
+		t.Errorf("Unexpected next run day. Expected %s got %s", time.Monday, weekday)
+	}
+}
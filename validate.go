@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var monthMap = map[string]string{
@@ -20,6 +21,18 @@ var monthMap = map[string]string{
 	"OCT": "10",
 	"NOV": "11",
 	"DEC": "12",
+
+	"JANUARY":   "1",
+	"FEBRUARY":  "2",
+	"MARCH":     "3",
+	"APRIL":     "4",
+	"JUNE":      "6",
+	"JULY":      "7",
+	"AUGUST":    "8",
+	"SEPTEMBER": "9",
+	"OCTOBER":   "10",
+	"NOVEMBER":  "11",
+	"DECEMBER":  "12",
 }
 
 var weekdayMap = map[string]string{
@@ -30,76 +43,285 @@ var weekdayMap = map[string]string{
 	"THU": "4",
 	"FRI": "5",
 	"SAT": "6",
+
+	"SUNDAY":    "0",
+	"MONDAY":    "1",
+	"TUESDAY":   "2",
+	"WEDNESDAY": "3",
+	"THURSDAY":  "4",
+	"FRIDAY":    "5",
+	"SATURDAY":  "6",
 }
 
-var alphabeticalPattern = regexp.MustCompile("[A-Z]{3}")
+// namedElementPattern matches a single element that is entirely a name, such as JAN or Monday. It is anchored so that
+// mixed elements, such as 5ABC, are not mistaken for names.
+var namedElementPattern = regexp.MustCompile("^[A-Za-z]+$")
+
+var nearestWeekdayPattern = regexp.MustCompile("(?i)^(L|[0-9]+)W$")
 
 // Validate will ensure that the job pattern is valid and return an error with any validation error
 func (job Job) Validate() error {
-	if job.Pattern == "* * * * *" {
+	if job.Window != nil {
+		if err := job.Window.validate(); err != nil {
+			return err
+		}
+	}
+	if job.Every < 0 {
+		return validationErrorf(ErrInvalidEvery, "invalid every value: must be positive")
+	}
+	if job.At != nil {
+		if job.every() > 0 {
+			return validationErrorf(ErrInvalidEvery, "invalid at value: can't be used with every")
+		}
+		return nil
+	}
+	if job.Every > 0 {
 		return nil
 	}
-	components := strings.Split(job.Pattern, " ")
-	if len(components) != 5 {
-		return fmt.Errorf("invalid number of date components")
+	if strings.HasPrefix(job.Pattern, everyPrefix) {
+		return validateEveryPattern(job.Pattern)
 	}
+	for _, pattern := range job.patternStrings() {
+		if job.Seconds {
+			seconds, rest, err := splitSeconds(pattern)
+			if err != nil {
+				return err
+			}
+			if err := validateComponent(seconds, "second", secondComponent); err != nil {
+				return err
+			}
+			pattern = rest
+		}
 
-	dateUnits := []string{
-		"minute",
-		"hour",
-		"day on month",
-		"month",
-		"day of week",
+		if err := validatePattern(pattern); err != nil {
+			return err
+		}
 	}
 
-	for i, component := range components {
-		if component == "*" {
-			continue
+	return nil
+}
+
+// ValidateStrict will ensure that the job pattern is valid, like Validate, and also that every pattern can actually
+// run. Patterns where the day of month never occurs in any of the months, such as 0 0 31 4 * or 0 0 30 2 *, are valid
+// but will never run, and are rejected by this method.
+func (job Job) ValidateStrict() error {
+	if err := job.Validate(); err != nil {
+		return err
+	}
+	if job.every() > 0 {
+		return nil
+	}
+
+	patterns := job.patternStrings()
+	for i, pattern := range job.parse() {
+		if _, ok := nextRun([]parsedPattern{pattern}, time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)); !ok {
+			return validationErrorf(ErrNeverRuns, "invalid day of month: day %s never occurs in month %s in pattern '%s'", pattern.components[2], pattern.components[3], patterns[i])
 		}
+	}
+	return nil
+}
 
-		unit := dateUnits[i]
+// dateUnits are the names of each of the 5 components of a pattern, used when describing errors
+var dateUnits = []string{
+	"minute",
+	"hour",
+	"day of month",
+	"month",
+	"day of week",
+}
 
-		if strings.ContainsRune(component, '/') {
-			if err := validateExpression(component, unit, i); err != nil {
-				return err
-			}
-		} else if strings.ContainsRune(component, '-') {
-			if err := validateRange(component, unit, i); err != nil {
-				return err
-			}
-		} else if strings.ContainsRune(component, ',') {
-			if err := validateList(component, unit, i); err != nil {
-				return err
-			}
-		} else if alphabeticalPattern.MatchString(component) {
-			if err := validateName(component, unit, i); err != nil {
-				return err
-			}
-		} else {
-			v, err := strconv.Atoi(component)
-			if err != nil {
-				return fmt.Errorf("invalid %s value: %s", unit, err.Error())
-			}
-			if !validateDateComponent(v, i) {
-				return fmt.Errorf("invalid %s value", unit)
+// Validate ensures that every job in this tab is valid and that no two jobs share the same name. The returned error
+// describes every invalid job, not just the first, and matches the error of each of them with errors.Is.
+func (s *Tab) Validate() error {
+	problems := []error{}
+	if err := validateJobNames(s.Jobs); err != nil {
+		problems = append(problems, err)
+	}
+	for i, job := range s.Jobs {
+		if err := job.Validate(); err != nil {
+			if job.Name == "" {
+				problems = append(problems, fmt.Errorf("job %d: %w", i, err))
+			} else {
+				problems = append(problems, fmt.Errorf("job '%s': %w", job.Name, err))
 			}
 		}
 	}
 
+	if len(problems) == 0 {
+		return nil
+	}
+	return &tabValidationError{problems: problems}
+}
+
+// everyPrefix is the prefix of a pattern that runs at a fixed interval, such as @every 1h30m
+const everyPrefix = "@every "
+
+// validateEveryPattern validates a pattern that runs at a fixed interval, such as @every 1h30m
+func validateEveryPattern(pattern string) error {
+	d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(pattern, everyPrefix)))
+	if err != nil {
+		return validationErrorf(ErrInvalidEvery, "invalid every value: %s", err.Error())
+	}
+	if d <= 0 {
+		return validationErrorf(ErrInvalidEvery, "invalid every value: must be positive")
+	}
+	return nil
+}
+
+// nicknames are shorthands for common patterns, which are expanded before the pattern is validated or parsed
+var nicknames = map[string]string{
+	"@weekdays": "* * * * 1-5",
+	"@weekends": "* * * * 0,6",
+}
+
+// expandNickname returns the pattern that the given nickname is a shorthand for, or the pattern unchanged if it isn't a
+// nickname
+func expandNickname(pattern string) string {
+	if expanded, ok := nicknames[strings.ToLower(pattern)]; ok {
+		return expanded
+	}
+	return pattern
+}
+
+// isNickname returns true if the pattern is a shorthand for another pattern, such as @weekdays
+func isNickname(pattern string) bool {
+	_, ok := nicknames[strings.ToLower(pattern)]
+	return ok
+}
+
+// validatePattern validates the 5 components of a pattern
+func validatePattern(pattern string) error {
+	pattern = expandNickname(pattern)
+	if pattern == "* * * * *" {
+		return nil
+	}
+	components := strings.Split(pattern, " ")
+	if len(components) < 5 {
+		return validationErrorf(ErrTooFewFields, "invalid number of date components")
+	} else if len(components) > 5 {
+		return validationErrorf(ErrTooManyFields, "invalid number of date components")
+	}
+
+	for i, component := range components {
+		if err := validateComponent(component, dateUnits[i], i); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateComponent validates a single component of a pattern
+func validateComponent(component string, unit string, i int) error {
+	if i == 1 {
+		component = replaceMeridiem(component)
+	}
+	if component == "*" || component == randomComponent {
+		return nil
+	}
+
+	if hashedPattern.MatchString(component) {
+		return validateHashed(component, unit, i)
+	}
+	if nearestWeekdayPattern.MatchString(component) {
+		return validateNearestWeekday(component, unit, i)
+	}
+
+	if err := validateNamePlacement(component, unit, i); err != nil {
+		return err
+	}
+
+	if strings.ContainsRune(component, ',') {
+		return validateList(component, unit, i)
+	} else if strings.ContainsAny(component, "-/") {
+		return validateElement(component, unit, i)
+	} else if namedElementPattern.MatchString(component) {
+		return validateName(component, unit, i)
+	}
+	return validateElement(component, unit, i)
+}
+
+// splitSeconds splits the leading seconds component from a pattern that includes seconds
+func splitSeconds(pattern string) (seconds string, rest string, err error) {
+	idx := strings.IndexRune(pattern, ' ')
+	if idx < 0 {
+		return "", "", validationErrorf(ErrTooFewFields, "invalid number of date components")
+	}
+	return pattern[:idx], pattern[idx+1:], nil
+}
+
+// validateJobNames ensures that no two jobs share the same non-empty name
+func validateJobNames(jobs []Job) error {
+	names := map[string]bool{}
+	for _, job := range jobs {
+		if job.Name == "" {
+			continue
+		}
+		if names[job.Name] {
+			return validationErrorf(ErrDuplicateName, "duplicate job name '%s'", job.Name)
+		}
+		names[job.Name] = true
+	}
+
+	return nil
+}
+
+// validateElement validates a single value, range, or expression. Lists are made up of one or more elements.
+func validateElement(element string, unit string, i int) error {
+	if strings.ContainsRune(element, '/') {
+		return validateExpression(element, unit, i)
+	} else if strings.ContainsRune(element, '-') {
+		return validateRange(element, unit, i)
+	}
+
+	v, err := strconv.Atoi(element)
+	if err != nil {
+		return validationErrorf(ErrInvalidValue, "invalid %s value: %s", unit, err.Error())
+	}
+	if !validateDateComponent(v, i) {
+		return boundsError(unit, "value", i)
+	}
 	return nil
 }
 
 func validateExpression(component string, unit string, i int) error {
 	parts := strings.Split(component, "/")
 	if len(parts) > 2 {
-		return fmt.Errorf("invalid %s expression", unit)
+		return validationErrorf(ErrInvalidStep, "invalid %s expression", unit)
+	}
+	if parts[0] == "" || parts[1] == "" {
+		return validationErrorf(ErrInvalidStep, "empty value in %s expression", unit)
+	}
+	if parts[0] != "*" && !strings.ContainsRune(parts[0], '-') {
+		// A step applied to a single value, such as 7/30
+		start, err := rangeValue(parts[0], i)
+		if err != nil {
+			return validationErrorf(ErrInvalidStep, "invalid %s expression: %s", unit, err.Error())
+		}
+		if !validateDateComponent(start, i) {
+			return boundsError(unit, "expression", i)
+		}
+	} else if parts[0] != "*" {
+		if err := validateRange(parts[0], unit, i); err != nil {
+			return err
+		}
+		bounds := strings.Split(parts[0], "-")
+		left, _ := rangeValue(bounds[0], i)
+		right, _ := rangeValue(bounds[1], i)
+		if left > right {
+			return validationErrorf(ErrInvalidStep, "invalid %s expression: a step can't be applied to a range that wraps around", unit)
+		}
 	}
 	value, err := strconv.Atoi(parts[1])
 	if err != nil {
-		return fmt.Errorf("invalid %s expression: %s", unit, err.Error())
+		return validationErrorf(ErrInvalidStep, "invalid %s expression: %s", unit, err.Error())
+	}
+	if value < 1 {
+		return boundsError(unit, "expression", i)
 	}
 	if !validateDateComponent(value, i) {
-		return fmt.Errorf("invalid %s expression", unit)
+		// A step larger than the field can only ever match the first value, which is almost certainly a mistake
+		return validationErrorf(ErrInvalidStep, "invalid %s expression: step %d is larger than the field, %s must be %s", unit, value, unit, componentBounds(i))
 	}
 
 	return nil
@@ -108,40 +330,116 @@ func validateExpression(component string, unit string, i int) error {
 func validateRange(component string, unit string, i int) error {
 	parts := strings.Split(component, "-")
 	if len(parts) > 2 {
-		return fmt.Errorf("invalid %s range", unit)
+		return validationErrorf(ErrInvalidRange, "invalid %s range", unit)
 	}
-	left, err := strconv.Atoi(parts[0])
+	if parts[0] == "" || parts[1] == "" {
+		return validationErrorf(ErrInvalidRange, "empty value in %s range", unit)
+	}
+	left, err := rangeValue(parts[0], i)
 	if err != nil {
-		return fmt.Errorf("invalid %s range: %s", unit, err.Error())
+		return validationErrorf(ErrInvalidRange, "invalid %s range: %s", unit, err.Error())
 	}
-	right, err := strconv.Atoi(parts[1])
+	right, err := rangeValue(parts[1], i)
 	if err != nil {
-		return fmt.Errorf("invalid %s range: %s", unit, err.Error())
+		return validationErrorf(ErrInvalidRange, "invalid %s range: %s", unit, err.Error())
 	}
-	if left > right || left == right {
-		return fmt.Errorf("invalid %s range", unit)
+	// Only day of week ranges may wrap around, such as FRI-MON
+	if left > right && i != 4 {
+		return validationErrorf(ErrInvalidRange, "invalid %s range", unit)
 	}
 	if !validateDateComponent(left, i) || !validateDateComponent(right, i) {
-		return fmt.Errorf("invalid %s expression", unit)
+		return boundsError(unit, "range", i)
 	}
 
 	return nil
 }
 
+// rangeValue returns the numerical value of one side of a range, which may be a named value for month and day of week
+// components
+func rangeValue(value string, i int) (int, error) {
+	if i == 3 {
+		if v, ok := monthMap[strings.ToUpper(value)]; ok {
+			value = v
+		}
+	} else if i == 4 {
+		if v, ok := weekdayMap[strings.ToUpper(value)]; ok {
+			value = v
+		}
+	}
+	return strconv.Atoi(value)
+}
+
+// validateList validates a comma-separated list, where each element of the list may be a value, range, or expression
 func validateList(component string, unit string, i int) error {
 	for _, part := range strings.Split(component, ",") {
+		if part == "" {
+			return validationErrorf(ErrInvalidList, "empty value in %s list", unit)
+		}
+		if strings.ContainsAny(part, "-/") {
+			if err := validateElement(part, unit, i); err != nil {
+				return err
+			}
+			continue
+		}
+		if namedElementPattern.MatchString(part) {
+			if err := validateName(part, unit, i); err != nil {
+				return err
+			}
+			continue
+		}
+
 		value, err := strconv.Atoi(part)
 		if err != nil {
-			return fmt.Errorf("invalid %s list: %s", unit, err.Error())
+			return validationErrorf(ErrInvalidList, "invalid %s list: %s", unit, err.Error())
 		}
 		if !validateDateComponent(value, i) {
-			return fmt.Errorf("invalid %s list", unit)
+			return boundsError(unit, "list", i)
 		}
 	}
 
 	return nil
 }
 
+// validateNamePlacement ensures that named values, including those used in ranges, lists, or expressions, only appear
+// in the month and day of week components
+func validateNamePlacement(component string, unit string, i int) error {
+	if i == 3 || i == 4 {
+		return nil
+	}
+
+	elements := strings.FieldsFunc(component, func(r rune) bool {
+		return r == ',' || r == '-' || r == '/'
+	})
+	for _, element := range elements {
+		if namedElementPattern.MatchString(element) {
+			return validationErrorf(ErrInvalidName, "invalid %s value %s: named values are only allowed in month and day-of-week fields", unit, element)
+		}
+	}
+
+	return nil
+}
+
+// validateNearestWeekday validates a nearest weekday component, such as 15W or LW, which is only allowed as the day of
+// month
+func validateNearestWeekday(component string, unit string, i int) error {
+	if i != 2 {
+		return validationErrorf(ErrInvalidValue, "invalid %s value %s: W is only allowed in the day-of-month field", unit, component)
+	}
+
+	day := strings.TrimSuffix(strings.ToUpper(component), "W")
+	if day == "L" {
+		return nil
+	}
+	v, err := strconv.Atoi(day)
+	if err != nil {
+		return validationErrorf(ErrInvalidValue, "invalid %s value: %s", unit, err.Error())
+	}
+	if !validateDateComponent(v, i) {
+		return boundsError(unit, "value", i)
+	}
+	return nil
+}
+
 func validateName(component string, unit string, i int) error {
 	var m map[string]string
 	if i == 3 {
@@ -149,16 +447,54 @@ func validateName(component string, unit string, i int) error {
 	} else if i == 4 {
 		m = weekdayMap
 	} else {
-		return fmt.Errorf("invalid %s value", unit)
+		return validationErrorf(ErrInvalidName, "invalid %s value", unit)
 	}
 
-	if _, ok := m[component]; !ok {
-		return fmt.Errorf("invalid %s value", unit)
+	if _, ok := m[strings.ToUpper(component)]; !ok {
+		return validationErrorf(ErrInvalidName, "invalid %s value: %s must be %s", unit, unit, componentBounds(i))
 	}
 
 	return nil
 }
 
+// secondComponent is the index used for the seconds component when validating patterns that include seconds
+const secondComponent = 5
+
+// boundsError returns an error for a value that is outside of the allowed range for the component, describing the
+// allowed range
+func boundsError(unit string, kind string, i int) error {
+	err := ErrInvalidValue
+	switch kind {
+	case "range":
+		err = ErrInvalidRange
+	case "expression":
+		err = ErrInvalidStep
+	case "list":
+		err = ErrInvalidList
+	}
+	return validationErrorf(err, "invalid %s %s: %s must be %s", unit, kind, unit, componentBounds(i))
+}
+
+// componentBounds describes the allowed values for the component
+func componentBounds(i int) string {
+	switch i {
+	case 0:
+		return "0-59"
+	case 1:
+		return "0-23"
+	case 2:
+		return "1-31"
+	case 3:
+		return "1-12 or JAN-DEC"
+	case 4:
+		return "0-6 or SUN-SAT"
+	case secondComponent:
+		return "0-59"
+	}
+
+	return ""
+}
+
 func validateDateComponent(v int, unit int) bool {
 	switch unit {
 	case 0:
@@ -171,17 +507,23 @@ func validateDateComponent(v int, unit int) bool {
 		return validateMonth(v)
 	case 4:
 		return validateDayOfWeek(v)
+	case secondComponent:
+		return validateSecond(v)
 	}
 
 	return false
 }
 
+func validateSecond(v int) bool {
+	return v >= 0 && v <= 59
+}
+
 func validateMinute(v int) bool {
-	return v >= 0 && v <= 60
+	return v >= 0 && v <= 59
 }
 
 func validateHour(v int) bool {
-	return v >= 0 && v <= 24
+	return v >= 0 && v <= 23
 }
 
 func validateDayOfMonth(v int) bool {
@@ -199,6 +541,7 @@ func validateDayOfWeek(v int) bool {
 // getRealPattern will return each of the 5 components from the given pattern converting any named values to their
 // numerical equals. This assumes the pattern has already been validated and will panic on invalid patterns.
 func getRealPattern(pattern string) []string {
+	pattern = expandNickname(pattern)
 	if pattern == "* * * * *" {
 		return []string{"*", "*", "*", "*", "*"}
 	}
@@ -210,13 +553,23 @@ func getRealPattern(pattern string) []string {
 	month := components[3]
 	dayOfWeek := components[4]
 
-	// Replace any named values (I.E. JAN or WED) with their numerical values
-	if alphabeticalPattern.MatchString(month) {
-		month = monthMap[month]
-	}
-	if alphabeticalPattern.MatchString(dayOfWeek) {
-		dayOfWeek = weekdayMap[dayOfWeek]
-	}
+	// Replace any named values (I.E. JAN or WED) with their numerical values, and any 12-hour values (I.E. 9AM) with
+	// their 24-hour values
+	hour = replaceMeridiem(hour)
+	month = replaceNames(month, monthMap)
+	dayOfWeek = replaceNames(dayOfWeek, weekdayMap)
 
 	return []string{minute, hour, dayOfMonth, month, dayOfWeek}
 }
+
+var namePattern = regexp.MustCompile("[A-Z]+")
+
+// replaceNames replaces every named value in the component, including those used in ranges, with its numerical value
+func replaceNames(component string, names map[string]string) string {
+	return namePattern.ReplaceAllStringFunc(component, func(name string) string {
+		if value, ok := names[name]; ok {
+			return value
+		}
+		return name
+	})
+}
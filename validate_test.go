@@ -1,7 +1,10 @@
 package cron_test
 
 import (
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ecnepsnai/cron"
 )
@@ -43,3 +46,373 @@ func TestValidate(t *testing.T) {
 	expect(false, "0 0 * MON JAN")
 	expect(false, "0 NULL 1 MON *")
 }
+
+func TestValidateMisplacedNames(t *testing.T) {
+	t.Parallel()
+
+	expect := func(p string) {
+		j := cron.Job{Pattern: p}
+		err := j.Validate()
+		if err == nil {
+			t.Errorf("No error seen for pattern with misplaced names '%s'", p)
+			return
+		}
+		if !strings.Contains(err.Error(), "named values are only allowed in month and day-of-week fields") {
+			t.Errorf("Unexpected error for pattern '%s': %s", p, err.Error())
+		}
+	}
+
+	expect("JAN-MAR * * * *")
+	expect("0 MON,TUE * * *")
+	expect("0 0 */FRI * *")
+	expect("0 0 JAN * *")
+}
+
+func TestValidateListGrammar(t *testing.T) {
+	t.Parallel()
+
+	expect := func(e bool, p string) {
+		j := cron.Job{Pattern: p}
+		r := j.Validate()
+		if (r == nil) != e {
+			t.Errorf("Incorrect validation result for pattern '%s'. Error: %v", p, r)
+		}
+	}
+
+	expect(true, "1-5,10 * * * *")
+	expect(true, "*/15,7 * * * *")
+	expect(true, "0-30/10,45 * * * *")
+	expect(true, "1,2/3 * * * *")
+	expect(true, "1/2,3 * * * *")
+	expect(false, "1,*/0 * * * *")
+	expect(false, "*/0 * * * *")
+	expect(false, "1,2-3-4 * * * *")
+	expect(false, "1,5-2 * * * *")
+	expect(false, "1,2/3/4 * * * *")
+
+	err := cron.Job{Pattern: "1,60/3 * * * *"}.Validate()
+	if err == nil || !strings.Contains(err.Error(), "minute must be 0-59") {
+		t.Errorf("Unexpected error for step applied to an invalid value: %v", err)
+	}
+}
+
+func TestValidateSeconds(t *testing.T) {
+	t.Parallel()
+
+	expect := func(e bool, p string) {
+		j := cron.Job{Pattern: p, Seconds: true}
+		r := j.Validate()
+		if (r == nil) != e {
+			t.Errorf("Incorrect validation result for seconds pattern '%s'. Error: %v", p, r)
+		}
+	}
+
+	expect(true, "* * * * * *")
+	expect(true, "*/15 * * * * *")
+	expect(true, "0,30 0 * * * *")
+	expect(false, "* * * * *")
+	expect(false, "60 * * * * *")
+	expect(false, "JAN * * * * *")
+	expect(false, "*")
+}
+
+func TestValidateErrorBounds(t *testing.T) {
+	t.Parallel()
+
+	expect := func(p string, message string) {
+		err := cron.Job{Pattern: p}.Validate()
+		if err == nil {
+			t.Errorf("No error seen for pattern '%s'", p)
+			return
+		}
+		if !strings.Contains(err.Error(), message) {
+			t.Errorf("Error for pattern '%s' does not describe bounds. Expected '%s' in '%s'", p, message, err.Error())
+		}
+	}
+
+	expect("60 * * * *", "minute must be 0-59")
+	expect("0 24 * * *", "hour must be 0-23")
+	expect("0 0 32 * *", "day of month must be 1-31")
+	expect("0 0 * 13 *", "month must be 1-12 or JAN-DEC")
+	expect("0 0 * * 8", "day of week must be 0-6 or SUN-SAT")
+	expect("0 0 * * FOO", "day of week must be 0-6 or SUN-SAT")
+	expect("0 0-24 * * *", "hour must be 0-23")
+	expect("0 1,25 * * *", "hour must be 0-23")
+	expect("*/61 * * * *", "minute must be 0-59")
+	expect("*/60 * * * *", "step 60 is larger than the field")
+	expect("*/90 * * * *", "step 90 is larger than the field")
+	expect("0 */24 * * *", "step 24 is larger than the field")
+}
+
+func TestValidateNamedRanges(t *testing.T) {
+	t.Parallel()
+
+	expect := func(e bool, p string) {
+		j := cron.Job{Pattern: p}
+		r := j.Validate()
+		if (r == nil) != e {
+			t.Errorf("Incorrect validation result for pattern '%s'. Error: %v", p, r)
+		}
+	}
+
+	expect(true, "0 0 * * MON-FRI")
+	expect(true, "0 0 * * FRI-MON")
+	expect(true, "0 0 * * 5-1")
+	expect(true, "0 0 * JAN-MAR *")
+	expect(false, "0 0 * MAR-JAN *")
+	expect(false, "0 0 * * MON-FOO")
+	expect(false, "0 0 * * FRI-MON/2")
+	expect(false, "0 5-1 * * *")
+}
+
+func TestValidateFullNames(t *testing.T) {
+	t.Parallel()
+
+	expect := func(e bool, p string) {
+		j := cron.Job{Pattern: p}
+		r := j.Validate()
+		if (r == nil) != e {
+			t.Errorf("Incorrect validation result for pattern '%s'. Error: %v", p, r)
+		}
+	}
+
+	expect(true, "0 0 * December Friday")
+	expect(true, "0 0 * SEPTEMBER *")
+	expect(true, "0 0 * * Monday-Friday")
+	expect(false, "0 0 * Decembre *")
+	expect(false, "0 0 * * Fryday")
+	expect(false, "0 0 * Friday December")
+
+	minute, hour, dayOfMonth, month, dayOfWeek := cron.Job{Pattern: "0 0 * December Friday"}.Fields()
+	if result := strings.Join([]string{minute, hour, dayOfMonth, month, dayOfWeek}, " "); result != "0 0 * 12 5" {
+		t.Errorf("Incorrect fields for pattern with full names: '%s'", result)
+	}
+}
+
+func TestValidateNearestWeekday(t *testing.T) {
+	t.Parallel()
+
+	expect := func(e bool, p string) {
+		r := cron.Job{Pattern: p}.Validate()
+		if e && r != nil {
+			t.Errorf("Unexpected error validating pattern '%s': %s", p, r.Error())
+		} else if !e && r == nil {
+			t.Errorf("No error seen for invalid pattern '%s'", p)
+		}
+	}
+
+	expect(true, "0 0 15W * *")
+	expect(true, "0 0 1W * *")
+	expect(true, "0 0 31W * *")
+	expect(true, "0 0 LW * *")
+	expect(true, "0 0 lw * *")
+	expect(false, "0 0 32W * *")
+	expect(false, "0 0 0W * *")
+	expect(false, "0 0 W * *")
+	expect(false, "0 0 1W,15W * *")
+	expect(false, "15W * * * *")
+	expect(false, "0 0 * * 1W")
+	expect(false, "0 0 * LW *")
+}
+
+func TestValidateNamedLists(t *testing.T) {
+	t.Parallel()
+
+	expect := func(e bool, p string) {
+		j := cron.Job{Pattern: p}
+		r := j.Validate()
+		if (r == nil) != e {
+			t.Errorf("Incorrect validation result for pattern '%s'. Error: %v", p, r)
+		}
+	}
+
+	expect(true, "0 0 1 JAN,JUL *")
+	expect(true, "0 0 1 jan,Jul,12 *")
+	expect(true, "0 0 * * MON,WED,FRI")
+	expect(true, "0 0 * * MON,3,saturday")
+	expect(true, "0 0 * * MON-WED,FRI")
+	expect(false, "0 0 * * MON,FOO")
+	expect(false, "0 0 1 JAN,MON *")
+	expect(false, "0 0 * * MON,JAN")
+}
+
+func TestValidateTab(t *testing.T) {
+	t.Parallel()
+
+	tab, err := cron.New([]cron.Job{
+		{
+			Name:    "Valid",
+			Pattern: "0 0 * * *",
+		},
+		{
+			Name:    "AlsoValid",
+			Pattern: "0 1 * * *",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if err := tab.Validate(); err != nil {
+		t.Fatalf("Unexpected error validating valid tab: %s", err.Error())
+	}
+
+	tab.Jobs[1].Pattern = "0 25 * * *"
+	tab.Jobs = append(tab.Jobs, cron.Job{Pattern: "* * *"})
+	err = tab.Validate()
+	if err == nil {
+		t.Fatalf("No error seen for tab with invalid jobs")
+	}
+	if strings.Contains(err.Error(), "'Valid'") {
+		t.Errorf("Error names valid job: %s", err.Error())
+	}
+	if !strings.Contains(err.Error(), "job 'AlsoValid': invalid hour value") {
+		t.Errorf("Error does not name invalid job: %s", err.Error())
+	}
+	if !strings.Contains(err.Error(), "job 2: invalid number of date components") {
+		t.Errorf("Error does not describe unnamed invalid job: %s", err.Error())
+	}
+}
+
+func TestValidateMixedNames(t *testing.T) {
+	t.Parallel()
+
+	expect := func(e bool, p string) {
+		r := cron.Job{Pattern: p}.Validate()
+		if (r == nil) != e {
+			t.Errorf("Incorrect validation result for pattern '%s'. Error: %v", p, r)
+		}
+	}
+
+	expect(true, "0 0 * JAN *")
+	expect(true, "0 0 * jan *")
+	expect(true, "0 0 * JAN,FEB *")
+	expect(true, "0 0 * * MON-FRI")
+	expect(false, "0 0 * 5ABC *")
+	expect(false, "0 0 * JAN5 *")
+	expect(false, "0 0 * ABCJAN *")
+	expect(false, "0 0 * JAN,5ABC *")
+	expect(false, "0 0 * * 1-5ABC")
+	expect(false, "0 0 * * MON-FRIX")
+	expect(false, "5ABC * * * *")
+	expect(false, "0 0 5ABC * *")
+}
+
+func TestValidateNameCase(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+	expectSame := func(patterns ...string) {
+		var expectedNormalized string
+		var expectedRuns []time.Time
+		for i, pattern := range patterns {
+			job := cron.Job{Pattern: pattern}
+			if err := job.Validate(); err != nil {
+				t.Errorf("Unexpected error validating pattern '%s': %s", pattern, err.Error())
+				continue
+			}
+			normalized, _ := cron.Normalize(pattern)
+			runs := job.RunsBetween(start, end)
+			if i == 0 {
+				expectedNormalized = normalized
+				expectedRuns = runs
+				continue
+			}
+			if normalized != expectedNormalized {
+				t.Errorf("Pattern '%s' normalized to '%s', expected '%s'", pattern, normalized, expectedNormalized)
+			}
+			if !reflect.DeepEqual(runs, expectedRuns) {
+				t.Errorf("Pattern '%s' runs at different times than '%s'", pattern, patterns[0])
+			}
+		}
+	}
+
+	expectSame("0 0 1 JAN *", "0 0 1 jan *", "0 0 1 Jan *", "0 0 1 jAN *")
+	expectSame("0 0 * * MON-FRI", "0 0 * * mon-fri", "0 0 * * Mon-Fri", "0 0 * * mon-FRI")
+	expectSame("0 0 * * MON,WED", "0 0 * * mon,Wed", "0 0 * * monday,WEDNESDAY")
+	expectSame("0 0 1 JAN-MAR/2 *", "0 0 1 jan-mar/2 *", "0 0 1 January-March/2 *")
+	expectSame("0 0 * * MON/2", "0 0 * * mon/2")
+}
+
+func TestValidateEveryPattern(t *testing.T) {
+	t.Parallel()
+
+	expect := func(e bool, p string) {
+		r := cron.Job{Pattern: p}.Validate()
+		if (r == nil) != e {
+			t.Errorf("Incorrect validation result for pattern '%s'. Error: %v", p, r)
+		}
+	}
+
+	expect(true, "@every 90s")
+	expect(true, "@every 1h30m")
+	expect(false, "@every garbage")
+	expect(false, "@every -5m")
+	expect(false, "@every 0s")
+	expect(false, "@every")
+
+	job := cron.Job{Pattern: "@every 1h"}
+	if _, ok := job.NextRun(time.Now()); ok {
+		t.Errorf("Unexpected next run for @every job")
+	}
+	if job.WouldRunNow() {
+		t.Errorf("@every job should never report that it would run now")
+	}
+}
+
+func TestValidateEmptyElements(t *testing.T) {
+	t.Parallel()
+
+	expect := func(p string, message string) {
+		err := cron.Job{Pattern: p}.Validate()
+		if err == nil {
+			t.Errorf("No error seen for pattern '%s'", p)
+			return
+		}
+		if err.Error() != message {
+			t.Errorf("Unexpected error for pattern '%s'. Expected '%s' got '%s'", p, message, err.Error())
+		}
+	}
+
+	expect("0,,5 * * * *", "empty value in minute list")
+	expect(",5 * * * *", "empty value in minute list")
+	expect("5, * * * *", "empty value in minute list")
+	expect("0- * * * *", "empty value in minute range")
+	expect("0 -5 * * *", "empty value in hour range")
+	expect("0 0 1,2- * *", "empty value in day of month range")
+	expect("*/ * * * *", "empty value in minute expression")
+	expect("0 0 * * MON,", "empty value in day of week list")
+}
+
+func TestValidateStrict(t *testing.T) {
+	t.Parallel()
+
+	expect := func(strict bool, p string) {
+		if _, err := cron.New([]cron.Job{{Pattern: p}}); err != nil {
+			t.Errorf("Unexpected error creating tab with pattern '%s': %s", p, err.Error())
+		}
+		_, err := cron.NewStrict([]cron.Job{{Pattern: p}})
+		if strict && err != nil {
+			t.Errorf("Unexpected strict error for pattern '%s': %s", p, err.Error())
+		} else if !strict && err == nil {
+			t.Errorf("No strict error seen for pattern '%s'", p)
+		}
+	}
+
+	expect(false, "0 0 31 4 *")
+	expect(false, "0 0 30 2 *")
+	expect(false, "0 0 31 APR,JUN,SEP,NOV *")
+	expect(false, "0 0 31W 4 *")
+	expect(true, "0 0 29 2 *")
+	expect(true, "0 0 31 * *")
+	expect(true, "0 0 31 4,5 *")
+	expect(true, "0 0 30-31 4 *")
+	// The day of week is OR-d with the day of month, so the job still runs on Mondays in April
+	expect(true, "0 0 31 4 MON")
+	expect(true, "@every 1h")
+
+	err := cron.Job{Pattern: "0 0 31 4 *"}.ValidateStrict()
+	if err == nil || !strings.Contains(err.Error(), "day 31 never occurs in month 4") {
+		t.Errorf("Unexpected strict error: %v", err)
+	}
+}
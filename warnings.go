@@ -0,0 +1,113 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cadenceSampleSize is how many runs of a job are inspected to find how often it can run
+const cadenceSampleSize = 200
+
+// Warnings returns a description of any likely misconfiguration of this tab, such as a job that is meant to run more
+// often than the tab checks for jobs to run. Warnings are also logged when the tab starts.
+func (s *Tab) Warnings() []string {
+	warnings := []string{}
+	if len(s.Jobs) == 0 {
+		warnings = append(warnings, "tab has no jobs, it will not run anything")
+	}
+	for _, job := range s.Jobs {
+		cadence, ok := job.minimumCadence()
+		if !ok {
+			continue
+		}
+		if cadence < s.Interval {
+			warnings = append(warnings, fmt.Sprintf("job '%s' can run every %s but the tab only checks every %s", job.Name, cadence, s.Interval))
+		}
+	}
+	for _, job := range s.Jobs {
+		for _, step := range job.oversizedSteps() {
+			warnings = append(warnings, fmt.Sprintf("job '%s' has a step larger than its range in the %s field '%s', it will only ever match once", job.Name, step.unit, step.element))
+		}
+	}
+	return warnings
+}
+
+// minimumCadence returns the shortest time between any two runs of this job. False is returned if the job is invalid
+// or runs at most once.
+func (job Job) minimumCadence() (time.Duration, bool) {
+	if job.every() > 0 {
+		return job.every(), true
+	}
+	if job.Validate() != nil {
+		return 0, false
+	}
+
+	finest := time.Minute
+	if job.Seconds {
+		finest = time.Second
+	}
+
+	next := job.RunIterator(time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond))
+	last, ok := next()
+	if !ok {
+		return 0, false
+	}
+	var cadence time.Duration
+	for i := 0; i < cadenceSampleSize; i++ {
+		run, ok := next()
+		if !ok {
+			break
+		}
+		if gap := run.Sub(last); cadence == 0 || gap < cadence {
+			cadence = gap
+		}
+		if cadence == finest {
+			break
+		}
+		last = run
+	}
+
+	return cadence, cadence > 0
+}
+
+// oversizedStep describes a stepped range where the step is larger than the range itself
+type oversizedStep struct {
+	unit    string
+	element string
+}
+
+// oversizedSteps returns every stepped range in this job's patterns where the step is larger than the range, such as
+// 0-10/20, which can only ever match the start of the range.
+func (job Job) oversizedSteps() []oversizedStep {
+	if job.every() > 0 || job.Validate() != nil {
+		return nil
+	}
+
+	steps := []oversizedStep{}
+	check := func(component string, unit string) {
+		for _, element := range strings.Split(component, ",") {
+			parts := strings.Split(element, "/")
+			if len(parts) != 2 || !strings.ContainsRune(parts[0], '-') {
+				continue
+			}
+			bounds := strings.Split(parts[0], "-")
+			left, _ := strconv.Atoi(bounds[0])
+			right, _ := strconv.Atoi(bounds[1])
+			step, _ := strconv.Atoi(parts[1])
+			if step > right-left {
+				steps = append(steps, oversizedStep{unit, element})
+			}
+		}
+	}
+	for _, pattern := range job.parse() {
+		if pattern.seconds != "" {
+			check(pattern.seconds, "second")
+		}
+		for i, component := range pattern.components {
+			check(component, dateUnits[i])
+		}
+	}
+	return steps
+}
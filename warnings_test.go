@@ -0,0 +1,99 @@
+package cron_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestTabWarnings(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := cron.New([]cron.Job{
+		{
+			Name:    "EveryMinute",
+			Pattern: "* * * * *",
+		},
+		{
+			Name:    "Hourly",
+			Pattern: "0 * * * *",
+		},
+		{
+			Name:    "TwiceAnHour",
+			Pattern: "0,3 * * * *",
+		},
+		{
+			Name:  "Every30Seconds",
+			Every: 30 * time.Second,
+		},
+	})
+
+	if warnings := tab.Warnings(); len(warnings) != 1 || !strings.Contains(warnings[0], "'Every30Seconds'") {
+		t.Errorf("Unexpected warnings for default interval: %v", warnings)
+	}
+
+	tab.Interval = 5 * time.Minute
+	warnings := tab.Warnings()
+	if len(warnings) != 3 {
+		t.Fatalf("Unexpected number of warnings. Expected %d got %d: %v", 3, len(warnings), warnings)
+	}
+	if !strings.Contains(warnings[0], "'EveryMinute' can run every 1m0s but the tab only checks every 5m0s") {
+		t.Errorf("Unexpected warning '%s'", warnings[0])
+	}
+	if !strings.Contains(warnings[1], "'TwiceAnHour' can run every 3m0s") {
+		t.Errorf("Unexpected warning '%s'", warnings[1])
+	}
+}
+
+func TestTabWarningsOversizedStep(t *testing.T) {
+	t.Parallel()
+
+	tab, err := cron.New([]cron.Job{
+		{
+			Name:    "Oversized",
+			Pattern: "0-10/20 * * * *",
+		},
+		{
+			Name:    "Fine",
+			Pattern: "0-30/15 * * * *",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	warnings := tab.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("Unexpected number of warnings. Expected %d got %d: %v", 1, len(warnings), warnings)
+	}
+	if !strings.Contains(warnings[0], "'Oversized' has a step larger than its range in the minute field '0-10/20'") {
+		t.Errorf("Unexpected warning '%s'", warnings[0])
+	}
+}
+
+func TestTabWarningsEmpty(t *testing.T) {
+	t.Parallel()
+
+	tab, err := cron.New([]cron.Job{})
+	if err != nil {
+		t.Fatalf("Unexpected error creating empty tab: %s", err.Error())
+	}
+	warnings := tab.Warnings()
+	if len(warnings) != 1 || warnings[0] != "tab has no jobs, it will not run anything" {
+		t.Errorf("Unexpected warnings for empty tab: %v", warnings)
+	}
+
+	// An empty tab can still be started and stopped
+	tab.Interval = 1 * time.Millisecond
+	stop := tab.Run()
+	time.Sleep(5 * time.Millisecond)
+	if !tab.Running() {
+		t.Errorf("Empty tab is not running")
+	}
+	stop()
+	if tab.Running() || tab.TotalRuns() != 0 {
+		t.Errorf("Unexpected state for stopped empty tab")
+	}
+}
@@ -0,0 +1,81 @@
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// withWeekStart returns a copy of the job where numerical day of week values in its parsed patterns are counted from
+// the week start of the tab rather than from Sunday. Named values, such as MON, and nicknames, such as @weekdays, are
+// not changed.
+func (s *Tab) withWeekStart(job Job) Job {
+	if s.WeekStart == time.Sunday || job.every() > 0 || job.At != nil {
+		return job
+	}
+
+	patterns := job.patternStrings()
+	parsed := job.parse()
+	shifted := make([]parsedPattern, len(parsed))
+	for i, pattern := range parsed {
+		raw := patterns[i]
+		if job.Seconds {
+			_, raw, _ = splitSeconds(raw)
+		}
+		if isNickname(raw) {
+			// The days of nicknames, such as @weekdays, are named days and are not changed
+			shifted[i] = pattern
+			continue
+		}
+		rawComponents := strings.Split(raw, " ")
+		if len(rawComponents) != 5 {
+			rawComponents = []string{"*", "*", "*", "*", "*"}
+		}
+		components := append([]string{}, pattern.components...)
+		components[4] = shiftWeekdays(rawComponents[4], components[4], s.WeekStart)
+		shifted[i] = parsedPattern{
+			components: components,
+			seconds:    pattern.seconds,
+		}
+	}
+	job.parsed = shifted
+	return job
+}
+
+// shiftWeekdays returns the parsed day of week component with each numerical value counted from the given week start,
+// as a list of values counted from Sunday. Elements of the raw component that use names are not changed.
+func shiftWeekdays(raw string, parsed string, weekStart time.Weekday) string {
+	if parsed == "*" {
+		return parsed
+	}
+	rawElements := strings.Split(raw, ",")
+	parsedElements := strings.Split(parsed, ",")
+	if len(rawElements) != len(parsedElements) {
+		// The component was resolved from a hash or random value
+		return parsed
+	}
+
+	elements := []string{}
+	days := make([]bool, 7)
+	for i, element := range parsedElements {
+		if strings.IndexFunc(rawElements[i], isLetter) >= 0 {
+			elements = append(elements, element)
+			continue
+		}
+		for day := 0; day < 7; day++ {
+			if elementMatches(element, day) {
+				days[(day+int(weekStart))%7] = true
+			}
+		}
+	}
+	for day, matches := range days {
+		if matches {
+			elements = append(elements, strconv.Itoa(day))
+		}
+	}
+	return strings.Join(elements, ",")
+}
+
+func isLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
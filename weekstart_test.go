@@ -0,0 +1,77 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestWeekStart(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := cron.New([]cron.Job{
+		{Name: "Zero", Pattern: "0 0 * * 0"},
+		{Name: "Six", Pattern: "0 0 * * 6"},
+		{Name: "Weekdays", Pattern: "0 0 * * 0-4"},
+		{Name: "EveryOtherDay", Pattern: "0 0 * * */2"},
+		{Name: "Monday", Pattern: "0 0 * * MON"},
+		{Name: "Mixed", Pattern: "0 0 * * SAT,0"},
+	})
+	tab.TZ = time.UTC
+	tab.WeekStart = time.Monday
+
+	// January 4th 2021 is a Monday
+	monday := time.Date(2021, time.January, 4, 0, 0, 0, 0, time.UTC)
+	expect := func(day time.Time, expected ...string) {
+		names := []string{}
+		for _, job := range tab.DueAt(day) {
+			names = append(names, job.Name)
+		}
+		if len(names) != len(expected) {
+			t.Errorf("Unexpected jobs due on %s. Expected %v got %v", day.Weekday(), expected, names)
+			return
+		}
+		for i := range names {
+			if names[i] != expected[i] {
+				t.Errorf("Unexpected jobs due on %s. Expected %v got %v", day.Weekday(), expected, names)
+				return
+			}
+		}
+	}
+
+	expect(monday, "Zero", "Weekdays", "EveryOtherDay", "Monday", "Mixed")
+	expect(monday.AddDate(0, 0, 1), "Weekdays")
+	expect(monday.AddDate(0, 0, 2), "Weekdays", "EveryOtherDay")
+	expect(monday.AddDate(0, 0, 4), "Weekdays", "EveryOtherDay")
+	expect(monday.AddDate(0, 0, 5), "Mixed")
+	expect(monday.AddDate(0, 0, 6), "Six", "EveryOtherDay")
+
+	// By default weeks start on Sunday
+	tab.WeekStart = time.Sunday
+	expect(monday, "Weekdays", "Monday")
+}
+
+func TestWeekStartNicknames(t *testing.T) {
+	t.Parallel()
+
+	tab, _ := cron.New([]cron.Job{
+		{Name: "Weekdays", Pattern: "@weekdays"},
+		{Name: "Weekends", Pattern: "@weekends"},
+	})
+	tab.TZ = time.UTC
+	tab.WeekStart = time.Monday
+
+	// January 3rd 2021 is a Sunday
+	for i := 0; i < 7; i++ {
+		day := time.Date(2021, time.January, 3+i, 12, 0, 0, 0, time.UTC)
+		due := tab.DueAt(day)
+		expected := "Weekdays"
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			expected = "Weekends"
+		}
+		if len(due) != 1 || due[0].Name != expected {
+			t.Errorf("Unexpected jobs due on %s with weeks starting on Monday: %v", day.Weekday(), due)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package cron
+
+import (
+	"time"
+)
+
+// maxWindowSearch is how many windows to look through when finding the next run of a job with a Window
+const maxWindowSearch = 1000
+
+// Window limits a job to running during a period of each day, such as business hours. Times are compared in the
+// timezone that the job's pattern is matched in.
+type Window struct {
+	// The time of day that the window starts, as the duration since midnight, such as 9 * time.Hour for 9AM
+	Start time.Duration `json:"start"`
+	// The time of day that the window ends, as the duration since midnight, such as 17 * time.Hour for 5PM. The end is
+	// not included in the window. If End is before Start then the window continues past midnight into the next day, and
+	// if End is equal to Start then the window is the entire day.
+	End time.Duration `json:"end"`
+	// The days of the week that the window starts on. If empty, the window starts on every day. For windows that
+	// continue past midnight, only the day that the window started on is considered.
+	Weekdays []time.Weekday `json:"weekdays,omitempty"`
+}
+
+// BusinessHours returns a window from 9AM to 5PM, Monday to Friday
+func BusinessHours() *Window {
+	return &Window{
+		Start:    9 * time.Hour,
+		End:      17 * time.Hour,
+		Weekdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	}
+}
+
+// Contains returns true if the given time, in its location, is within the window
+func (w Window) Contains(t time.Time) bool {
+	day := midnight(t)
+	offset := timeOfDay(t)
+	if w.End > w.Start {
+		return offset >= w.Start && offset < w.End && w.startsOn(day.Weekday())
+	}
+	if offset >= w.Start {
+		return w.startsOn(day.Weekday())
+	}
+	return offset < w.End && w.startsOn(day.AddDate(0, 0, -1).Weekday())
+}
+
+// timeOfDay returns the time shown on the clock at t as the duration since midnight, which differs from the time
+// elapsed since midnight on days when daylight saving time begins or ends
+func timeOfDay(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+}
+
+// startsOn returns true if the window starts on the given day of the week
+func (w Window) startsOn(weekday time.Weekday) bool {
+	if len(w.Weekdays) == 0 {
+		return true
+	}
+	for _, d := range w.Weekdays {
+		if d == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// nextStart returns the first time after the given time, in its location, that the window starts
+func (w Window) nextStart(after time.Time) time.Time {
+	hour, minute := int(w.Start/time.Hour), int(w.Start%time.Hour/time.Minute)
+	second, nanosecond := int(w.Start%time.Minute/time.Second), int(w.Start%time.Second)
+	for i := 0; ; i++ {
+		start := time.Date(after.Year(), after.Month(), after.Day()+i, hour, minute, second, nanosecond, after.Location())
+		if start.After(after) && w.startsOn(start.Weekday()) {
+			return start
+		}
+	}
+}
+
+// validate ensures that the start and end of the window are times of day and that the weekdays are valid
+func (w Window) validate() error {
+	if w.Start < 0 || w.Start >= 24*time.Hour || w.End < 0 || w.End >= 24*time.Hour {
+		return validationErrorf(ErrInvalidWindow, "invalid window: start and end must be between 0 and 24h")
+	}
+	for _, weekday := range w.Weekdays {
+		if weekday < time.Sunday || weekday > time.Saturday {
+			return validationErrorf(ErrInvalidWindow, "invalid window: invalid weekday %d", weekday)
+		}
+	}
+	return nil
+}
+
+// withinWindow wraps the given method that finds the next run of the job so that only runs within the job's window are
+// returned
+func (job Job) withinWindow(find func(after time.Time) (time.Time, bool)) func(after time.Time) (time.Time, bool) {
+	if job.Window == nil {
+		return find
+	}
+	return func(after time.Time) (time.Time, bool) {
+		for i := 0; i < maxWindowSearch; i++ {
+			next, ok := find(after)
+			if !ok {
+				return time.Time{}, false
+			}
+			if job.Window.Contains(next) {
+				return next, true
+			}
+			// Skip ahead to just before the window next opens
+			after = job.Window.nextStart(next).Add(-time.Nanosecond)
+		}
+		return time.Time{}, false
+	}
+}
@@ -0,0 +1,104 @@
+package cron_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestWindowContains(t *testing.T) {
+	t.Parallel()
+
+	expect := func(window cron.Window, clock time.Time, expected bool) {
+		if actual := window.Contains(clock); actual != expected {
+			t.Errorf("Unexpected result for %s in window %+v. Expected %v got %v", clock, window, expected, actual)
+		}
+	}
+
+	business := *cron.BusinessHours()
+	expect(business, time.Date(2021, time.January, 4, 8, 59, 0, 0, time.UTC), false)
+	expect(business, time.Date(2021, time.January, 4, 9, 0, 0, 0, time.UTC), true)
+	expect(business, time.Date(2021, time.January, 4, 16, 59, 0, 0, time.UTC), true)
+	expect(business, time.Date(2021, time.January, 4, 17, 0, 0, 0, time.UTC), false)
+	expect(business, time.Date(2021, time.January, 9, 12, 0, 0, 0, time.UTC), false)
+
+	// Overnight windows belong to the day they started on
+	overnight := cron.Window{Start: 22 * time.Hour, End: 2 * time.Hour, Weekdays: []time.Weekday{time.Friday}}
+	expect(overnight, time.Date(2021, time.January, 8, 23, 0, 0, 0, time.UTC), true)
+	expect(overnight, time.Date(2021, time.January, 9, 1, 59, 0, 0, time.UTC), true)
+	expect(overnight, time.Date(2021, time.January, 9, 2, 0, 0, 0, time.UTC), false)
+	expect(overnight, time.Date(2021, time.January, 9, 23, 0, 0, 0, time.UTC), false)
+	expect(overnight, time.Date(2021, time.January, 8, 1, 0, 0, 0, time.UTC), false)
+
+	expect(cron.Window{}, time.Date(2021, time.January, 8, 1, 0, 0, 0, time.UTC), true)
+}
+
+func TestWindowJob(t *testing.T) {
+	t.Parallel()
+
+	job := cron.Job{Pattern: "* * * * *", Window: cron.BusinessHours()}
+	if err := job.Validate(); err != nil {
+		t.Fatalf("Unexpected error validating job: %s", err.Error())
+	}
+	if job.WouldRunNow(cron.WithTime(time.Date(2021, time.January, 4, 8, 59, 0, 0, time.UTC))) {
+		t.Errorf("Job would run before the window")
+	}
+	if !job.WouldRunNow(cron.WithTime(time.Date(2021, time.January, 4, 9, 0, 0, 0, time.UTC))) {
+		t.Errorf("Job would not run within the window")
+	}
+
+	// Friday evening to Monday morning
+	next, ok := job.NextRun(time.Date(2021, time.January, 8, 16, 59, 0, 0, time.UTC))
+	if !ok || !next.Equal(time.Date(2021, time.January, 11, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected next run: %s", next)
+	}
+
+	invalid := cron.Job{Pattern: "* * * * *", Window: &cron.Window{Start: 25 * time.Hour}}
+	if err := invalid.Validate(); !errors.Is(err, cron.ErrInvalidWindow) {
+		t.Errorf("Unexpected error for invalid window: %v", err)
+	}
+}
+
+func TestWindowDaylightSaving(t *testing.T) {
+	t.Parallel()
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("Timezone data not available: %s", err.Error())
+	}
+
+	// Daylight saving time begins at 2AM on March 10th 2024 in New York, and ends at 2AM on November 3rd 2024
+	window := cron.Window{Start: 9 * time.Hour, End: 17 * time.Hour}
+	for _, date := range []time.Time{
+		time.Date(2024, time.March, 10, 0, 0, 0, 0, newYork),
+		time.Date(2024, time.November, 3, 0, 0, 0, 0, newYork),
+	} {
+		eightThirty := time.Date(date.Year(), date.Month(), date.Day(), 8, 30, 0, 0, newYork)
+		if window.Contains(eightThirty) {
+			t.Errorf("Window contains %s", eightThirty)
+		}
+		nineThirty := time.Date(date.Year(), date.Month(), date.Day(), 9, 30, 0, 0, newYork)
+		if !window.Contains(nineThirty) {
+			t.Errorf("Window does not contain %s", nineThirty)
+		}
+		fiveThirty := time.Date(date.Year(), date.Month(), date.Day(), 17, 30, 0, 0, newYork)
+		if window.Contains(fiveThirty) {
+			t.Errorf("Window contains %s", fiveThirty)
+		}
+
+		job := cron.Job{Pattern: "0 * * * *", Window: &window}
+		runs := job.RunsBetween(date, date.AddDate(0, 0, 1).Add(-time.Minute))
+		if len(runs) != 8 || runs[0].Hour() != 9 || runs[7].Hour() != 16 {
+			t.Errorf("Unexpected runs on %s: %v", date.Format("2006-01-02"), runs)
+		}
+	}
+
+	// The next window starts at 9AM on the clock, even across the change
+	job := cron.Job{Pattern: "0 * * * *", Window: &window}
+	next, ok := job.NextRun(time.Date(2024, time.March, 9, 18, 0, 0, 0, newYork))
+	if !ok || !next.Equal(time.Date(2024, time.March, 10, 9, 0, 0, 0, newYork)) {
+		t.Errorf("Unexpected next run %s", next)
+	}
+}
@@ -0,0 +1,78 @@
+package cron
+
+// QueuePolicy describes what happens when a job is due but the queue of a tab using Workers is full
+type QueuePolicy int
+
+const (
+	// QueueBlock waits until there is room in the queue. The tab does not check for any other jobs to run while
+	// waiting, so ticks may be missed if the queue stays full.
+	QueueBlock QueuePolicy = iota
+	// QueueDropOldest removes the job that has been waiting in the queue the longest to make room. Dropped jobs are
+	// skipped with SkipQueueFull.
+	QueueDropOldest
+)
+
+// dispatchToWorkers adds the job to the queue of the tab's worker pool, starting the pool if needed
+func (s *Tab) dispatchToWorkers(job Job) {
+	queue := s.startWorkers()
+	s.inFlight.Add(1)
+	if s.QueuePolicy != QueueDropOldest {
+		queue <- job
+		return
+	}
+
+	for {
+		select {
+		case queue <- job:
+			return
+		default:
+		}
+		select {
+		case dropped := <-queue:
+			log.PWarn("Dropped queued job", map[string]interface{}{
+				"name": dropped.Name,
+			})
+			s.skip(dropped, SkipQueueFull)
+			s.inFlight.Done()
+		default:
+		}
+	}
+}
+
+// startWorkers starts the worker pool of the tab, if it isn't already running, and returns its queue
+func (s *Tab) startWorkers() chan Job {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.queue != nil {
+		return s.queue
+	}
+
+	size := s.QueueSize
+	if size <= 0 {
+		size = s.Workers
+	}
+	queue := make(chan Job, size)
+	for i := 0; i < s.Workers; i++ {
+		go func() {
+			for job := range queue {
+				if s.waitJitter(job) {
+					s.runJob(job)
+				}
+				s.inFlight.Done()
+			}
+		}()
+	}
+	s.queue = queue
+	return queue
+}
+
+// stopWorkers stops the worker pool of the tab, if it is running. Jobs that are already in the queue are still run.
+func (s *Tab) stopWorkers() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.queue == nil {
+		return
+	}
+	close(s.queue)
+	s.queue = nil
+}
@@ -0,0 +1,90 @@
+package cron_test
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestWorkers(t *testing.T) {
+	t.Parallel()
+
+	var lock sync.Mutex
+	current := 0
+	highest := 0
+	jobs := []cron.Job{}
+	for i := 0; i < 6; i++ {
+		jobs = append(jobs, cron.Job{
+			Name:    fmt.Sprintf("Slow%d", i),
+			Pattern: "* * * * *",
+			Exec: func() {
+				lock.Lock()
+				current++
+				if current > highest {
+					highest = current
+				}
+				lock.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				lock.Lock()
+				current--
+				lock.Unlock()
+			},
+		})
+	}
+	tab, _ := cron.New(jobs)
+	tab.Workers = 2
+
+	stop := tab.Run()
+	waitFor(t, "jobs to run", func() bool { return tab.TotalRuns() == 6 })
+	stop()
+
+	lock.Lock()
+	defer lock.Unlock()
+	if highest > 2 {
+		t.Errorf("Too many jobs ran at once. Expected at most %d got %d", 2, highest)
+	}
+	if current != 0 {
+		t.Errorf("Jobs still running after the tab stopped")
+	}
+}
+
+func TestWorkersDropOldest(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	jobs := []cron.Job{}
+	for i := 0; i < 4; i++ {
+		jobs = append(jobs, cron.Job{
+			Name:    fmt.Sprintf("Blocked%d", i),
+			Pattern: "* * * * *",
+			Exec: func() {
+				<-release
+			},
+		})
+	}
+	tab, _ := cron.New(jobs)
+	tab.Workers = 1
+	tab.QueueSize = 1
+	tab.QueuePolicy = cron.QueueDropOldest
+	var skipped atomic.Int32
+	tab.EventSink = func(event cron.Event) {
+		if event.Type == cron.EventSkip {
+			skipped.Add(1)
+		}
+	}
+
+	stop := tab.Run()
+	// Either the worker picks up the first job before the others are queued, or every job but the last is dropped
+	waitFor(t, "jobs to be dropped", func() bool { return skipped.Load() >= 2 })
+	close(release)
+	stop()
+
+	runs := tab.TotalRuns()
+	if runs > 2 || uint64(skipped.Load())+runs != 4 {
+		t.Errorf("Unexpected runs and drops. Got %d runs and %d drops", runs, skipped.Load())
+	}
+}
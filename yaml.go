@@ -0,0 +1,198 @@
+package cron
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// TabConfig describes the jobs of a tab in a configuration file
+type TabConfig struct {
+	// The jobs of the tab
+	Jobs []JobConfig `yaml:"jobs" json:"jobs"`
+}
+
+// JobConfig describes a single job in a configuration file. The method the job runs is provided separately by name.
+type JobConfig struct {
+	// The name of the job, used to find the method to run
+	Name string `yaml:"name" json:"name"`
+	// Cron pattern describing the schedule of this job
+	Pattern string `yaml:"pattern" json:"pattern"`
+	// Optional human readable description of this job
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// If true, this job is not added to the tab
+	Disabled bool `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+	// Optional tags for this job
+	Tags map[string]string `yaml:"tags,omitempty" json:"tags,omitempty"`
+}
+
+// FromYAML will create a new tab from the YAML document in data, where the method for each job is found in execs by the
+// name of the job. Disabled jobs are skipped. An error is returned if the document is malformed, if any job has an
+// invalid pattern, or if there is no method for a job.
+//
+// Only the subset of YAML needed to describe jobs is supported:
+//
+//	jobs:
+//	  - name: backup
+//	    pattern: "0 2 * * *"
+//	    description: Nightly backup
+//	    tags:
+//	      team: ops
+//	  - name: cleanup
+//	    pattern: "*/5 * * * *"
+//	    disabled: true
+func FromYAML(data []byte, execs map[string]func()) (*Tab, error) {
+	config, err := parseYAMLConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	return config.Tab(execs)
+}
+
+// Tab will create a new tab from the configuration, where the method for each job is found in execs by the name of the
+// job. Disabled jobs are skipped.
+func (config TabConfig) Tab(execs map[string]func()) (*Tab, error) {
+	jobs := []Job{}
+	for _, jobConfig := range config.Jobs {
+		if jobConfig.Disabled {
+			continue
+		}
+		exec, ok := execs[jobConfig.Name]
+		if !ok {
+			return nil, fmt.Errorf("job '%s': no method for job", jobConfig.Name)
+		}
+		job := Job{
+			Pattern:     jobConfig.Pattern,
+			Name:        jobConfig.Name,
+			Description: jobConfig.Description,
+			Tags:        jobConfig.Tags,
+			Exec:        exec,
+		}
+		if err := job.Validate(); err != nil {
+			return nil, fmt.Errorf("job '%s': %w", jobConfig.Name, err)
+		}
+		jobs = append(jobs, job)
+	}
+	return New(jobs)
+}
+
+// parseYAMLConfig parses the subset of YAML described by FromYAML
+func parseYAMLConfig(data []byte) (TabConfig, error) {
+	config := TabConfig{}
+	var job *JobConfig
+	inJobs := false
+	tagsIndent := -1
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(stripYAMLComment(scanner.Text()), " \r")
+		content := strings.TrimLeft(line, " ")
+		if content == "" || content == "---" {
+			continue
+		}
+		if strings.HasPrefix(content, "\t") {
+			return config, fmt.Errorf("line %d: tabs are not allowed for indentation", lineNumber)
+		}
+		indent := len(line) - len(content)
+
+		if indent == 0 {
+			key, value := splitYAMLPair(content)
+			if key != "jobs" || (value != "" && value != "[]") {
+				return config, fmt.Errorf("line %d: expected jobs", lineNumber)
+			}
+			inJobs = true
+			continue
+		}
+		if !inJobs {
+			return config, fmt.Errorf("line %d: expected jobs", lineNumber)
+		}
+
+		if tagsIndent >= 0 {
+			if indent > tagsIndent {
+				key, value := splitYAMLPair(content)
+				if key == "" {
+					return config, fmt.Errorf("line %d: invalid tag", lineNumber)
+				}
+				job.Tags[key] = value
+				continue
+			}
+			tagsIndent = -1
+		}
+
+		if strings.HasPrefix(content, "- ") || content == "-" {
+			config.Jobs = append(config.Jobs, JobConfig{})
+			job = &config.Jobs[len(config.Jobs)-1]
+			content = strings.TrimLeft(strings.TrimPrefix(content, "-"), " ")
+			indent = len(line) - len(content)
+			if content == "" {
+				continue
+			}
+		}
+		if job == nil {
+			return config, fmt.Errorf("line %d: expected a list of jobs", lineNumber)
+		}
+
+		key, value := splitYAMLPair(content)
+		switch key {
+		case "name":
+			job.Name = value
+		case "pattern":
+			job.Pattern = value
+		case "description":
+			job.Description = value
+		case "disabled":
+			switch value {
+			case "true":
+				job.Disabled = true
+			case "false":
+				job.Disabled = false
+			default:
+				return config, fmt.Errorf("line %d: invalid disabled value '%s'", lineNumber, value)
+			}
+		case "tags":
+			if value != "" && value != "{}" {
+				return config, fmt.Errorf("line %d: tags must be a mapping", lineNumber)
+			}
+			job.Tags = map[string]string{}
+			tagsIndent = indent
+		default:
+			return config, fmt.Errorf("line %d: unknown key '%s'", lineNumber, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+// splitYAMLPair splits a key: value line, removing any quotes from the value. An empty key is returned if the line is
+// not a pair.
+func splitYAMLPair(content string) (key string, value string) {
+	idx := strings.Index(content, ":")
+	if idx <= 0 {
+		return "", ""
+	}
+	return strings.TrimSpace(content[:idx]), unquoteVariable(content[idx+1:])
+}
+
+// stripYAMLComment removes a trailing comment from the line, ignoring any # inside of quotes
+func stripYAMLComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' '):
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -0,0 +1,70 @@
+package cron_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ecnepsnai/cron"
+)
+
+func TestFromYAML(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`# Scheduled jobs
+jobs:
+  - name: backup
+    pattern: "0 2 * * *" # every night
+    description: Nightly backup
+    tags:
+      team: ops
+      tier: '1'
+  - name: cleanup
+    pattern: '*/5 * * * *'
+  - name: report
+    pattern: "0 9 * * MON"
+    disabled: true
+`)
+
+	tab, err := cron.FromYAML(data, map[string]func(){
+		"backup":  func() {},
+		"cleanup": func() {},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if len(tab.Jobs) != 2 {
+		t.Fatalf("Unexpected number of jobs. Expected %d got %d", 2, len(tab.Jobs))
+	}
+
+	backup := tab.Jobs[0]
+	if backup.Name != "backup" || backup.Pattern != "0 2 * * *" || backup.Description != "Nightly backup" || backup.Exec == nil {
+		t.Errorf("Unexpected first job %+v", backup)
+	}
+	if len(backup.Tags) != 2 || backup.Tags["team"] != "ops" || backup.Tags["tier"] != "1" {
+		t.Errorf("Unexpected tags %v", backup.Tags)
+	}
+	if cleanup := tab.Jobs[1]; cleanup.Name != "cleanup" || cleanup.Pattern != "*/5 * * * *" || cleanup.Tags != nil {
+		t.Errorf("Unexpected second job %+v", cleanup)
+	}
+}
+
+func TestFromYAMLErrors(t *testing.T) {
+	t.Parallel()
+
+	expect := func(data string, message string) {
+		_, err := cron.FromYAML([]byte(data), map[string]func(){"backup": func() {}})
+		if err == nil {
+			t.Errorf("No error seen for document:\n%s", data)
+			return
+		}
+		if !strings.Contains(err.Error(), message) {
+			t.Errorf("Unexpected error. Expected '%s' in '%s'", message, err.Error())
+		}
+	}
+
+	expect("jobs:\n  - name: unknown\n    pattern: \"* * * * *\"\n", "job 'unknown': no method for job")
+	expect("jobs:\n  - name: backup\n    pattern: \"0 25 * * *\"\n", "job 'backup': invalid hour value")
+	expect("jobs:\n  - name: backup\n    schedule: \"* * * * *\"\n", "line 3: unknown key 'schedule'")
+	expect("tasks:\n  - name: backup\n", "line 1: expected jobs")
+	expect("jobs:\n  - name: backup\n    disabled: maybe\n", "line 3: invalid disabled value 'maybe'")
+}